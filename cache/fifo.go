@@ -14,15 +14,35 @@ type FIFOCache[K, V any] struct {
 	currentElements int
 	_list           *list.List
 	cache           map[any]*list.Element
+	tagIndex        map[string]map[any]struct{}
 
 	onRemoved func(k K, v V)
+	onAccess  func(k K, hit bool)
 }
 
 // cacheEntry represents a single entry in the FIFO cache.
-// It contains a key-value pair.
+// It contains a key-value pair and the tags associated with the entry.
 type cacheEntry struct {
 	key   any
 	value any
+	tags  []string
+}
+
+// NewFIFOCache creates a new FIFOCache instance.
+//
+//	params:
+//		- maxElements: the maximum number of entries kept in the cache. Values less than 1 are treated as 1.
+//		- threadSafe: whether the cache should guard its operations with a lock.
+func NewFIFOCache[K, V any](maxElements int, threadSafe bool) *FIFOCache[K, V] {
+	if maxElements < 1 {
+		maxElements = 1
+	}
+	return &FIFOCache[K, V]{
+		threadSafe:  threadSafe,
+		maxElements: maxElements,
+		_list:       list.New(),
+		cache:       make(map[any]*list.Element),
+	}
 }
 
 // SetOnRemovedCallBack register a call back function, it will be invoked when any entry is eliminating or removing.
@@ -34,10 +54,21 @@ func (c *FIFOCache[K, V]) SetOnRemovedCallBack(callback func(k K, v V)) {
 	c.onRemoved = callback
 }
 
+// SetOnAccessCallBack register a call back function, it will be invoked on every Get with whether the key was found.
+// It is invoked while the cache is locked, so it must not call methods of the cache.
+func (c *FIFOCache[K, V]) SetOnAccessCallBack(callback func(k K, hit bool)) {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+	c.onAccess = callback
+}
+
 // putAndOverwriteIfExist puts a new key-value pair into the FIFO cache.
 // If the key already exists, it either overwrites the existing value or retains the existing value based on the 'overwrite' parameter.
+// If tags is not nil, it replaces the tags associated with the entry.
 // It returns a boolean indicating whether the operation was successful.
-func (c *FIFOCache[K, V]) putAndOverwriteIfExist(k K, v V, overwrite bool) bool {
+func (c *FIFOCache[K, V]) putAndOverwriteIfExist(k K, v V, overwrite bool, tags []string) bool {
 	if c.threadSafe {
 		c.mu.Lock()
 		defer c.mu.Unlock()
@@ -52,7 +83,13 @@ func (c *FIFOCache[K, V]) putAndOverwriteIfExist(k K, v V, overwrite bool) bool
 			// Move the existing entry to the head of the list
 			c._list.MoveToFront(ele)
 			// Update the value of the existing entry
-			ele.Value.(*cacheEntry).value = v
+			entry := ele.Value.(*cacheEntry)
+			entry.value = v
+			if tags != nil {
+				c.unindexTags(entry)
+				entry.tags = tags
+				c.indexTags(entry)
+			}
 			return true // Operation successful
 		}
 		return false // Operation unsuccessful (key exists and overwrite is false)
@@ -60,19 +97,21 @@ func (c *FIFOCache[K, V]) putAndOverwriteIfExist(k K, v V, overwrite bool) bool
 
 	// If the key does not exist
 	// Create a new cache entry
-	newEntry := &cacheEntry{k, v}
+	newEntry := &cacheEntry{key: k, value: v, tags: tags}
 	// Put the new cache entry at the head of the list
 	newEle := c._list.PushFront(newEntry)
 	c.cache[k] = newEle
+	c.indexTags(newEntry)
 
 	// Check the count of elements
-	if c.currentElements > c.maxElements {
+	if c.currentElements >= c.maxElements {
 		// Eliminate a cache entry from the end of the list
 		eleEliminated := c._list.Back()
 		if eleEliminated != nil {
 			entryEliminated, _ := eleEliminated.Value.(*cacheEntry)
 			delete(c.cache, entryEliminated.key)
 			c._list.Remove(eleEliminated)
+			c.unindexTags(entryEliminated)
 			if c.onRemoved != nil {
 				c.onRemoved(entryEliminated.key.(K), entryEliminated.value.(V))
 			}
@@ -85,14 +124,44 @@ func (c *FIFOCache[K, V]) putAndOverwriteIfExist(k K, v V, overwrite bool) bool
 }
 
 // Put puts a new key-value pair into the FIFO cache, overwriting the existing value if the key already exists.
+// The tags associated with an existing entry are kept.
 func (c *FIFOCache[K, V]) Put(k K, v V) {
-	c.putAndOverwriteIfExist(k, v, true)
+	c.putAndOverwriteIfExist(k, v, true, nil)
+}
+
+// PutWithTags puts a new key-value pair associated with the given tags into the FIFO cache,
+// overwriting the existing value and tags if the key already exists.
+// All entries carrying a tag can be removed at once with InvalidateTag.
+func (c *FIFOCache[K, V]) PutWithTags(k K, v V, tags ...string) {
+	if tags == nil {
+		tags = []string{}
+	}
+	c.putAndOverwriteIfExist(k, v, true, tags)
 }
 
 // PutIfNotExist puts a new key-value pair into the FIFO cache if the key does not already exist.
 // It returns a boolean indicating whether the operation was successful (key did not exist in the cache).
 func (c *FIFOCache[K, V]) PutIfNotExist(k K, v V) bool {
-	return c.putAndOverwriteIfExist(k, v, false)
+	return c.putAndOverwriteIfExist(k, v, false, nil)
+}
+
+// Update replaces the value associated with the specified key without changing its position in the FIFO order.
+// It returns a boolean indicating whether the key existed in the cache.
+func (c *FIFOCache[K, V]) Update(k K, v V) bool {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	// Check if the key exists in the cache
+	ele, ok := c.cache[k]
+	if !ok {
+		return false // Key not found
+	}
+
+	// Update the value of the existing entry in place
+	ele.Value.(*cacheEntry).value = v
+	return true
 }
 
 // Get retrieves the value associated with the specified key from the FIFO cache.
@@ -105,6 +174,9 @@ func (c *FIFOCache[K, V]) Get(k K) (v V, found bool) {
 
 	// Check if the key exists in the cache
 	ele, ok := c.cache[k]
+	if c.onAccess != nil {
+		c.onAccess(k, ok)
+	}
 	if !ok {
 		return v, false // Key not found
 	}
@@ -113,6 +185,24 @@ func (c *FIFOCache[K, V]) Get(k K) (v V, found bool) {
 	return ele.Value.(*cacheEntry).value.(V), true // Return the value and indicate key found
 }
 
+// GetAll returns a snapshot of all entries in the FIFO cache as a map.
+// The returned map is a copy, so later changes of the cache are not reflected in it and vice versa.
+// It is a function rather than a method, because map keys need K to be comparable,
+// while FIFOCache accepts any key type.
+func GetAll[K comparable, V any](c *FIFOCache[K, V]) map[K]V {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	result := make(map[K]V, len(c.cache))
+	for _, ele := range c.cache {
+		entry := ele.Value.(*cacheEntry)
+		result[entry.key.(K)] = entry.value.(V)
+	}
+	return result
+}
+
 // Remove removes the entry with the specified key from the FIFO cache.
 // It returns a boolean indicating whether the entry was successfully removed.
 func (c *FIFOCache[K, V]) Remove(k K) bool {
@@ -127,6 +217,9 @@ func (c *FIFOCache[K, V]) Remove(k K) bool {
 		// Remove the entry from the linked list
 		c._list.Remove(ele)
 
+		// Remove the entry from the tag index
+		c.unindexTags(ele.Value.(*cacheEntry))
+
 		// Delete the entry from the cache map
 		delete(c.cache, k)
 
@@ -136,7 +229,7 @@ func (c *FIFOCache[K, V]) Remove(k K) bool {
 		// Trigger the onRemoved callback function, if provided
 		if c.onRemoved != nil {
 			entry, _ := ele.Value.(*cacheEntry)
-			c.onRemoved(entry.key, entry.value)
+			c.onRemoved(entry.key.(K), entry.value.(V))
 		}
 
 		return true // Entry successfully removed
@@ -171,6 +264,9 @@ func (c *FIFOCache[K, V]) Clear() {
 	// Create a new empty cache map
 	c.cache = make(map[interface{}]*list.Element)
 
+	// Drop the tag index
+	c.tagIndex = nil
+
 	// Create a new empty linked list
 	c._list = list.New()
 }
@@ -185,3 +281,64 @@ func (c *FIFOCache[K, V]) Size() int {
 	// Return the current number of elements in the cache
 	return c.currentElements
 }
+
+// InvalidateTag removes all entries carrying the specified tag from the FIFO cache.
+// The onRemoved callback is invoked for each removed entry.
+// It returns the number of removed entries.
+func (c *FIFOCache[K, V]) InvalidateTag(tag string) int {
+	if c.threadSafe {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+	}
+
+	keys := c.tagIndex[tag]
+	removed := 0
+	for k := range keys {
+		ele, ok := c.cache[k]
+		if !ok {
+			continue
+		}
+		entry := ele.Value.(*cacheEntry)
+		c._list.Remove(ele)
+		delete(c.cache, k)
+		c.unindexTags(entry)
+		c.currentElements--
+		removed++
+		if c.onRemoved != nil {
+			c.onRemoved(entry.key.(K), entry.value.(V))
+		}
+	}
+	return removed
+}
+
+// indexTags adds the entry key to the index of each of its tags.
+func (c *FIFOCache[K, V]) indexTags(entry *cacheEntry) {
+	if len(entry.tags) == 0 {
+		return
+	}
+	if c.tagIndex == nil {
+		c.tagIndex = make(map[string]map[any]struct{})
+	}
+	for _, tag := range entry.tags {
+		keys, ok := c.tagIndex[tag]
+		if !ok {
+			keys = make(map[any]struct{})
+			c.tagIndex[tag] = keys
+		}
+		keys[entry.key] = struct{}{}
+	}
+}
+
+// unindexTags removes the entry key from the index of each of its tags.
+func (c *FIFOCache[K, V]) unindexTags(entry *cacheEntry) {
+	for _, tag := range entry.tags {
+		keys, ok := c.tagIndex[tag]
+		if !ok {
+			continue
+		}
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.tagIndex, tag)
+		}
+	}
+}
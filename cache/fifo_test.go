@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFIFOCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	removed := make([]int, 0)
+	c := NewFIFOCache[int, string](2, true)
+	c.SetOnRemovedCallBack(func(k int, v string) {
+		removed = append(removed, k)
+	})
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(3, "c")
+
+	require.Equal(t, 2, c.Size())
+	require.False(t, c.Exist(1))
+	require.True(t, c.Exist(2))
+	require.True(t, c.Exist(3))
+	require.Equal(t, []int{1}, removed)
+
+	require.True(t, c.Remove(2))
+	require.Equal(t, 1, c.Size())
+	require.Equal(t, []int{1, 2}, removed)
+}
+
+func TestFIFOCacheUpdate(t *testing.T) {
+	t.Parallel()
+
+	c := NewFIFOCache[int, string](2, true)
+	require.False(t, c.Update(1, "a"))
+	require.False(t, c.Exist(1))
+
+	c.Put(1, "a")
+	c.Put(2, "b")
+	require.True(t, c.Update(1, "a2"))
+	v, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "a2", v)
+
+	// The updated oldest entry is still the next eviction candidate.
+	c.Put(3, "c")
+	require.False(t, c.Exist(1))
+	require.True(t, c.Exist(2))
+	require.True(t, c.Exist(3))
+}
+
+func TestFIFOCacheOnAccess(t *testing.T) {
+	t.Parallel()
+
+	type access struct {
+		key int
+		hit bool
+	}
+	accesses := make([]access, 0)
+	c := NewFIFOCache[int, string](2, true)
+	c.SetOnAccessCallBack(func(k int, hit bool) {
+		accesses = append(accesses, access{key: k, hit: hit})
+	})
+	c.Put(1, "a")
+
+	_, ok := c.Get(1)
+	require.True(t, ok)
+	_, ok = c.Get(2)
+	require.False(t, ok)
+	require.Equal(t, []access{{key: 1, hit: true}, {key: 2, hit: false}}, accesses)
+
+	c.SetOnAccessCallBack(nil)
+	c.Get(1)
+	require.Len(t, accesses, 2)
+}
+
+func TestFIFOCacheGetAll(t *testing.T) {
+	t.Parallel()
+
+	c := NewFIFOCache[int, string](2, true)
+	require.Equal(t, map[int]string{}, GetAll(c))
+
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(3, "c")
+	all := GetAll(c)
+	require.Equal(t, map[int]string{2: "b", 3: "c"}, all)
+
+	// The snapshot is independent of the cache.
+	all[4] = "d"
+	c.Put(2, "b2")
+	require.False(t, c.Exist(4))
+	require.Equal(t, "b", all[2])
+	require.Equal(t, map[int]string{2: "b2", 3: "c"}, GetAll(c))
+}
+
+func TestFIFOCacheInvalidateTag(t *testing.T) {
+	t.Parallel()
+
+	c := NewFIFOCache[string, int](10, true)
+	c.PutWithTags("a1", 1, "tenant-a")
+	c.PutWithTags("a2", 2, "tenant-a", "hot")
+	c.PutWithTags("b1", 3, "tenant-b", "hot")
+	c.Put("c1", 4)
+
+	require.Equal(t, 2, c.InvalidateTag("tenant-a"))
+	require.False(t, c.Exist("a1"))
+	require.False(t, c.Exist("a2"))
+	require.True(t, c.Exist("b1"))
+	require.True(t, c.Exist("c1"))
+	require.Equal(t, 2, c.Size())
+
+	// Re-tagging an entry replaces its tags
+	c.PutWithTags("b1", 5, "tenant-c")
+	require.Equal(t, 0, c.InvalidateTag("hot"))
+	require.Equal(t, 0, c.InvalidateTag("tenant-b"))
+	require.Equal(t, 1, c.InvalidateTag("tenant-c"))
+	require.False(t, c.Exist("b1"))
+	require.Equal(t, 0, c.InvalidateTag("unknown"))
+	require.Equal(t, 1, c.Size())
+}
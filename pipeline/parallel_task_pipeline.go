@@ -1,9 +1,17 @@
 package pipeline
 
 import (
+	"context"
 	"errors"
 )
 
+var (
+	// ErrPipelineClosed is returned when the pipeline is closed before the expected outputs arrive.
+	ErrPipelineClosed = errors.New("pipeline closed")
+	// ErrNoOutput is returned when collecting outputs from a pipeline configured to produce no output.
+	ErrNoOutput = errors.New("pipeline produces no output")
+)
+
 // Task defines the function signature of a task, which takes an input and returns an output and a boolean.
 // If the returned boolean is false, the task will be terminated and the job will be ignored.
 type Task func(input any) (output any, ok bool)
@@ -23,6 +31,48 @@ func (g GenericTaskProvider[I, O]) Task() Task {
 	}
 }
 
+// Middleware wraps a Task to add cross-cutting behavior such as logging, metrics or retry around it.
+type Middleware func(next Task) Task
+
+// Option defines the function signature of an option of ParallelTaskPipeline, applied at construction.
+type Option func(p *ParallelTaskPipeline) error
+
+// WithStageMiddleware adds a middleware wrapping the task of every stage.
+// Middlewares compose outermost-first, i.e. the first added middleware is the outermost one.
+func WithStageMiddleware(middleware Middleware) Option {
+	return func(p *ParallelTaskPipeline) error {
+		if middleware == nil {
+			return errors.New("nil stage middleware")
+		}
+		p.middlewares = append(p.middlewares, middleware)
+		return nil
+	}
+}
+
+// WithRouter sets a route function for the stage, which selects the index of the next stage
+// for each output of the stage, instead of always the stage right after it.
+// The route function must return an index greater than the stage and less than the pipeline count,
+// so that jobs only move forward, otherwise the job will be ignored.
+// The stage must not be the last one, and each stage can have only one router.
+func WithRouter(stage int, route func(output any) int) Option {
+	return func(p *ParallelTaskPipeline) error {
+		if route == nil {
+			return errors.New("nil router")
+		}
+		if stage < 0 || stage >= int(p.pipelineCount)-1 {
+			return errors.New("invalid router stage")
+		}
+		if p.routers == nil {
+			p.routers = make(map[uint8]func(output any) int)
+		}
+		if _, ok := p.routers[uint8(stage)]; ok {
+			return errors.New("duplicate router stage")
+		}
+		p.routers[uint8(stage)] = route
+		return nil
+	}
+}
+
 // Job struct represents a job to be executed in the pipeline.
 // It contains an input, output, a flag indicating if the job is successful, and a channel to signal job completion.
 type Job struct {
@@ -83,10 +133,14 @@ func (tp *taskPipeline) loop() {
 				if !job.Ok {
 					continue
 				}
+				next, ok := tp.nextIndex(job.Output)
+				if !ok {
+					continue
+				}
 				job.Input = job.Output
 				job.Output = nil
 				job.FinishedC = make(chan struct{})
-				job.tp = tp.ptp.pipelines[tp.index+1]
+				job.tp = tp.ptp.pipelines[next]
 				job.do()
 			}
 		case <-tp.ptp.closeC:
@@ -95,12 +149,29 @@ func (tp *taskPipeline) loop() {
 	}
 }
 
+// nextIndex returns the index of the pipeline the output should be forwarded to.
+// It returns false if the router of the pipeline selects an invalid index.
+func (tp *taskPipeline) nextIndex(output any) (uint8, bool) {
+	route, ok := tp.ptp.routers[tp.index]
+	if !ok {
+		return tp.index + 1, true
+	}
+	next := route(output)
+	if next <= int(tp.index) || next >= int(tp.ptp.pipelineCount) {
+		return 0, false
+	}
+	return uint8(next), true
+}
+
 // ParallelTaskPipeline struct represents the entire parallel task pipeline. It contains the count of pipelines,
 // an array of pipeline instances, and a channel for closing the pipeline.
 type ParallelTaskPipeline struct {
 	pipelineCount uint8
 	pipelines     []*taskPipeline
 
+	middlewares []Middleware
+	routers     map[uint8]func(output any) int
+
 	noOutput bool
 	outputC  chan any
 	closeC   chan struct{}
@@ -116,6 +187,17 @@ func RunParallelTaskPipeline(
 	pipelineCount uint8,
 	maxConcurrentQuantities []uint8,
 	pipelineTaskProviders ...TaskProvider,
+) (*ParallelTaskPipeline, error) {
+	return RunParallelTaskPipelineWithOptions(pipelineCount, maxConcurrentQuantities, pipelineTaskProviders)
+}
+
+// RunParallelTaskPipelineWithOptions is like RunParallelTaskPipeline,
+// but applies the given options before the pipelines start.
+func RunParallelTaskPipelineWithOptions(
+	pipelineCount uint8,
+	maxConcurrentQuantities []uint8,
+	pipelineTaskProviders []TaskProvider,
+	opts ...Option,
 ) (*ParallelTaskPipeline, error) {
 	if pipelineCount == 0 {
 		return nil, errors.New("invalid pipeline count")
@@ -133,11 +215,20 @@ func RunParallelTaskPipeline(
 		outputC:       make(chan any),
 		closeC:        make(chan struct{}),
 	}
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, err
+		}
+	}
 	for i := uint8(0); i < pipelineCount; i++ {
+		jobTask := pipelineTaskProviders[i].Task()
+		for j := len(p.middlewares) - 1; j >= 0; j-- {
+			jobTask = p.middlewares[j](jobTask)
+		}
 		tp := &taskPipeline{
 			index:   i,
 			jobC:    make(chan *Job, maxConcurrentQuantities[i]),
-			jobTask: pipelineTaskProviders[i].Task(),
+			jobTask: jobTask,
 			ptp:     p,
 		}
 		p.pipelines[i] = tp
@@ -179,3 +270,27 @@ func (p *ParallelTaskPipeline) OutputC() <-chan any {
 	}
 	return p.outputC
 }
+
+// CollectN reads n outputs from the pipeline and returns them as a slice in the order they arrive.
+// If ctx is done or the pipeline is closed before n outputs arrive, the outputs collected so far are returned
+// with ctx.Err() or ErrPipelineClosed. If the pipeline is configured to produce no output, ErrNoOutput is returned.
+func (p *ParallelTaskPipeline) CollectN(ctx context.Context, n int) ([]any, error) {
+	if p.noOutput {
+		return nil, ErrNoOutput
+	}
+	if n < 0 {
+		n = 0
+	}
+	outputs := make([]any, 0, n)
+	for len(outputs) < n {
+		select {
+		case <-ctx.Done():
+			return outputs, ctx.Err()
+		case <-p.closeC:
+			return outputs, ErrPipelineClosed
+		case output := <-p.outputC:
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
@@ -1,8 +1,12 @@
 package pipeline
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -50,3 +54,136 @@ func TestRunParallelTaskPipeline(t *testing.T) {
 		require.Equal(t, fmt.Sprintf("%s processed processed processed", job), (<-outputC).(string))
 	}
 }
+
+func TestRunParallelTaskPipelineWithStageMiddleware(t *testing.T) {
+	var count int64
+	counter := func(next Task) Task {
+		return func(input any) (any, bool) {
+			atomic.AddInt64(&count, 1)
+			return next(input)
+		}
+	}
+	tag := func(name string) Middleware {
+		return func(next Task) Task {
+			return func(input any) (any, bool) {
+				return next(fmt.Sprintf("%s[%s]", input, name))
+			}
+		}
+	}
+
+	ptp, err := RunParallelTaskPipelineWithOptions(
+		2,
+		[]uint8{2, 2},
+		[]TaskProvider{&MockTaskProvider{}, &MockTaskProvider{}},
+		WithStageMiddleware(counter),
+		WithStageMiddleware(tag("outer")),
+		WithStageMiddleware(tag("inner")),
+	)
+	require.NoError(t, err)
+	defer ptp.Close()
+
+	jobs := []string{"job1", "job2", "job3"}
+	for _, job := range jobs {
+		ptp.PushJob(job)
+	}
+	outputC := ptp.OutputC()
+	for _, job := range jobs {
+		require.Equal(t,
+			fmt.Sprintf("%s[outer][inner] processed[outer][inner] processed", job),
+			(<-outputC).(string),
+		)
+	}
+	require.Equal(t, int64(len(jobs)*2), atomic.LoadInt64(&count))
+
+	_, err = RunParallelTaskPipelineWithOptions(1, []uint8{1}, []TaskProvider{&MockTaskProvider{}}, WithStageMiddleware(nil))
+	require.Error(t, err)
+}
+
+func TestParallelTaskPipelineCollectN(t *testing.T) {
+	ptp, err := RunParallelTaskPipeline(2, []uint8{2, 2}, &MockTaskProvider{}, &MockTaskProvider{})
+	require.NoError(t, err)
+
+	jobs := []string{"job1", "job2", "job3"}
+	go func() {
+		for _, job := range jobs {
+			ptp.PushJob(job)
+		}
+	}()
+
+	outputs, err := ptp.CollectN(context.Background(), len(jobs))
+	require.NoError(t, err)
+	require.Len(t, outputs, len(jobs))
+	for i, job := range jobs {
+		require.Equal(t, fmt.Sprintf("%s processed processed", job), outputs[i])
+	}
+
+	// Cancellation returns the outputs collected so far
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	outputs, err = ptp.CollectN(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Empty(t, outputs)
+
+	ptp.Close()
+	_, err = ptp.CollectN(context.Background(), 1)
+	require.ErrorIs(t, err, ErrPipelineClosed)
+
+	_, err = ptp.NoOutput().CollectN(context.Background(), 1)
+	require.ErrorIs(t, err, ErrNoOutput)
+}
+
+func TestRunParallelTaskPipelineWithRouter(t *testing.T) {
+	toString := GenericTaskProvider[int, string](func(input int) (string, bool) {
+		return fmt.Sprint(input), true
+	})
+	even := GenericTaskProvider[string, string](func(input string) (string, bool) {
+		return input + " even", true
+	})
+	done := GenericTaskProvider[string, string](func(input string) (string, bool) {
+		return input + " done", true
+	})
+	route := func(output any) int {
+		n, _ := strconv.Atoi(output.(string))
+		if n < 0 {
+			// Routing backwards is invalid, the job is ignored
+			return 0
+		}
+		if n%2 == 0 {
+			return 1
+		}
+		return 2
+	}
+
+	ptp, err := RunParallelTaskPipelineWithOptions(3, []uint8{2, 2, 2}, []TaskProvider{toString, even, done},
+		WithRouter(0, route))
+	require.NoError(t, err)
+	defer ptp.Close()
+
+	go func() {
+		for _, n := range []int{1, 2, -1, 3, 4} {
+			ptp.PushJob(n)
+		}
+	}()
+	outputs, err := ptp.CollectN(context.Background(), 4)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []any{"1 done", "2 even done", "3 done", "4 even done"}, outputs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = ptp.CollectN(ctx, 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithRouterValidation(t *testing.T) {
+	providers := []TaskProvider{&MockTaskProvider{}, &MockTaskProvider{}}
+	route := func(output any) int { return 1 }
+
+	_, err := RunParallelTaskPipelineWithOptions(2, []uint8{1, 1}, providers, WithRouter(1, route))
+	require.Error(t, err)
+	_, err = RunParallelTaskPipelineWithOptions(2, []uint8{1, 1}, providers, WithRouter(-1, route))
+	require.Error(t, err)
+	_, err = RunParallelTaskPipelineWithOptions(2, []uint8{1, 1}, providers, WithRouter(0, nil))
+	require.Error(t, err)
+	_, err = RunParallelTaskPipelineWithOptions(2, []uint8{1, 1}, providers, WithRouter(0, route), WithRouter(0, route))
+	require.Error(t, err)
+}
@@ -0,0 +1,268 @@
+package pool
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultAutoScalingQueueSize is the default capacity of the task queue of AutoScalingPool.
+	DefaultAutoScalingQueueSize = 1024
+	// DefaultScaleUpThreshold is the default number of pending tasks above which a worker is added.
+	DefaultScaleUpThreshold = 0
+	// DefaultScaleUpCooldown is the default min time between two scale-up actions.
+	DefaultScaleUpCooldown = 100 * time.Millisecond
+	// DefaultIdleTimeout is the default time a worker waits for a task before exiting when above min workers.
+	DefaultIdleTimeout = 5 * time.Second
+	// DefaultScaleCheckInterval is the default interval at which the backlog is checked.
+	DefaultScaleCheckInterval = 50 * time.Millisecond
+)
+
+// ErrPoolClosed is returned when submitting a task to a closed pool.
+var ErrPoolClosed = errors.New("pool closed")
+
+// AutoScalingOption defines the function signature of an option of AutoScalingPool.
+type AutoScalingOption func(p *AutoScalingPool)
+
+// WithQueueSize sets the capacity of the task queue.
+// Values less than 1 are treated as DefaultAutoScalingQueueSize, since the backlog of an unbuffered queue
+// can not be observed to scale up.
+func WithQueueSize(size int) AutoScalingOption {
+	return func(p *AutoScalingPool) {
+		p.queueSize = size
+	}
+}
+
+// WithScaleUpThreshold sets the number of pending tasks above which a worker is added.
+func WithScaleUpThreshold(threshold int) AutoScalingOption {
+	return func(p *AutoScalingPool) {
+		p.scaleUpThreshold = threshold
+	}
+}
+
+// WithScaleUpCooldown sets the min time between two scale-up actions.
+func WithScaleUpCooldown(cooldown time.Duration) AutoScalingOption {
+	return func(p *AutoScalingPool) {
+		p.scaleUpCooldown = cooldown
+	}
+}
+
+// WithIdleTimeout sets the time a worker waits for a task before exiting when there are more than min workers.
+func WithIdleTimeout(timeout time.Duration) AutoScalingOption {
+	return func(p *AutoScalingPool) {
+		p.idleTimeout = timeout
+	}
+}
+
+// WithScaleCheckInterval sets the interval at which the backlog is checked.
+func WithScaleCheckInterval(interval time.Duration) AutoScalingOption {
+	return func(p *AutoScalingPool) {
+		p.checkInterval = interval
+	}
+}
+
+// AutoScalingPool is a worker pool that scales with the depth of its task queue.
+// It adds workers up to max while the backlog exceeds the scale-up threshold,
+// and lets idle workers exit until min workers remain.
+type AutoScalingPool struct {
+	mu     sync.RWMutex
+	closed bool
+
+	workersMu      sync.Mutex
+	workersStopped bool
+	wg             sync.WaitGroup
+
+	minWorkers       int
+	maxWorkers       int
+	runningWorkers   int64
+	queueSize        int
+	scaleUpThreshold int
+	scaleUpCooldown  time.Duration
+	idleTimeout      time.Duration
+	checkInterval    time.Duration
+
+	taskC  chan func()
+	closeC chan struct{}
+}
+
+// NewAutoScalingPool creates a new AutoScalingPool instance and starts min workers.
+//
+//	params:
+//		- minWorkers: the min number of workers kept running. Negative values are treated as 0.
+//		- maxWorkers: the max number of workers. Values less than max(minWorkers, 1) are raised to it.
+//		- opts: options for the queue size, thresholds, cooldown and intervals.
+func NewAutoScalingPool(minWorkers, maxWorkers int, opts ...AutoScalingOption) *AutoScalingPool {
+	if minWorkers < 0 {
+		minWorkers = 0
+	}
+	if maxWorkers < minWorkers {
+		maxWorkers = minWorkers
+	}
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	p := &AutoScalingPool{
+		minWorkers:       minWorkers,
+		maxWorkers:       maxWorkers,
+		queueSize:        DefaultAutoScalingQueueSize,
+		scaleUpThreshold: DefaultScaleUpThreshold,
+		scaleUpCooldown:  DefaultScaleUpCooldown,
+		idleTimeout:      DefaultIdleTimeout,
+		checkInterval:    DefaultScaleCheckInterval,
+		closeC:           make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.queueSize <= 0 {
+		p.queueSize = DefaultAutoScalingQueueSize
+	}
+	if p.checkInterval <= 0 {
+		p.checkInterval = DefaultScaleCheckInterval
+	}
+	p.taskC = make(chan func(), p.queueSize)
+	for i := 0; i < minWorkers; i++ {
+		p.startWorker()
+	}
+	go p.monitor()
+	return p
+}
+
+// Submit puts a task into the queue, blocking while the queue is full.
+// It returns ErrPoolClosed if the pool has been closed.
+func (p *AutoScalingPool) Submit(task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	p.taskC <- task
+	return nil
+}
+
+// PendingTasks returns the number of tasks waiting in the queue.
+func (p *AutoScalingPool) PendingTasks() int {
+	return len(p.taskC)
+}
+
+// RunningWorkers returns the number of running workers.
+func (p *AutoScalingPool) RunningWorkers() int {
+	return int(atomic.LoadInt64(&p.runningWorkers))
+}
+
+// Close stops accepting tasks and blocks until the queued tasks are done and all workers have exited.
+func (p *AutoScalingPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.closeC)
+	p.mu.Unlock()
+
+	p.workersMu.Lock()
+	p.workersStopped = true
+	p.workersMu.Unlock()
+	p.wg.Wait()
+
+	// With min workers 0, no worker may have been running to finish the queued tasks
+	for {
+		select {
+		case task := <-p.taskC:
+			task()
+		default:
+			return
+		}
+	}
+}
+
+// monitor checks the backlog periodically and adds a worker when it is above the threshold.
+func (p *AutoScalingPool) monitor() {
+	ticker := time.NewTicker(p.checkInterval)
+	defer ticker.Stop()
+	var lastScaleUp time.Time
+	for {
+		select {
+		case <-p.closeC:
+			return
+		case now := <-ticker.C:
+			if p.PendingTasks() <= p.scaleUpThreshold || now.Sub(lastScaleUp) < p.scaleUpCooldown {
+				continue
+			}
+			if p.tryStartWorker() {
+				lastScaleUp = now
+			}
+		}
+	}
+}
+
+// tryStartWorker starts a new worker if there are less than max workers running.
+// It does not take the submit lock, so that workers can still be added while a Submit blocks on a full queue.
+func (p *AutoScalingPool) tryStartWorker() bool {
+	p.workersMu.Lock()
+	defer p.workersMu.Unlock()
+	if p.workersStopped || p.RunningWorkers() >= p.maxWorkers {
+		return false
+	}
+	p.startWorker()
+	return true
+}
+
+// startWorker starts a new worker goroutine.
+func (p *AutoScalingPool) startWorker() {
+	atomic.AddInt64(&p.runningWorkers, 1)
+	p.wg.Add(1)
+	go p.worker()
+}
+
+// tryRetire decreases the running workers count if there are more than min workers running.
+func (p *AutoScalingPool) tryRetire() bool {
+	for {
+		running := atomic.LoadInt64(&p.runningWorkers)
+		if running <= int64(p.minWorkers) {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&p.runningWorkers, running, running-1) {
+			return true
+		}
+	}
+}
+
+// worker runs tasks from the queue until the pool is closed or it has been idle for too long.
+func (p *AutoScalingPool) worker() {
+	defer p.wg.Done()
+	idleTimer := time.NewTimer(p.idleTimeout)
+	defer idleTimer.Stop()
+	for {
+		select {
+		case task := <-p.taskC:
+			task()
+			if !idleTimer.Stop() {
+				select {
+				case <-idleTimer.C:
+				default:
+				}
+			}
+			idleTimer.Reset(p.idleTimeout)
+		case <-idleTimer.C:
+			if p.tryRetire() {
+				return
+			}
+			idleTimer.Reset(p.idleTimeout)
+		case <-p.closeC:
+			// Finish the queued tasks before exiting
+			for {
+				select {
+				case task := <-p.taskC:
+					task()
+				default:
+					atomic.AddInt64(&p.runningWorkers, -1)
+					return
+				}
+			}
+		}
+	}
+}
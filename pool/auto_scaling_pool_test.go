@@ -0,0 +1,90 @@
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoScalingPool(t *testing.T) {
+	p := NewAutoScalingPool(1, 4,
+		WithScaleUpCooldown(0),
+		WithScaleCheckInterval(5*time.Millisecond),
+		WithIdleTimeout(50*time.Millisecond),
+	)
+	require.Equal(t, 1, p.RunningWorkers())
+
+	var done int64
+	for i := 0; i < 50; i++ {
+		err := p.Submit(func() {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt64(&done, 1)
+		})
+		require.NoError(t, err)
+	}
+
+	// A sustained backlog scales up to max workers
+	require.Eventually(t, func() bool {
+		return p.RunningWorkers() == 4
+	}, time.Second, 5*time.Millisecond)
+
+	// Idleness scales down to min workers
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&done) == 50 && p.RunningWorkers() == 1
+	}, 2*time.Second, 5*time.Millisecond)
+	require.Equal(t, 0, p.PendingTasks())
+
+	p.Close()
+	require.Equal(t, 0, p.RunningWorkers())
+	require.ErrorIs(t, p.Submit(func() {}), ErrPoolClosed)
+}
+
+func TestAutoScalingPoolCloseRunsQueuedTasks(t *testing.T) {
+	p := NewAutoScalingPool(1, 1)
+	var done int64
+	for i := 0; i < 10; i++ {
+		require.NoError(t, p.Submit(func() {
+			atomic.AddInt64(&done, 1)
+		}))
+	}
+	p.Close()
+	require.Equal(t, int64(10), atomic.LoadInt64(&done))
+}
+
+func TestAutoScalingPoolCloseRunsQueuedTasksWithoutWorkers(t *testing.T) {
+	// The long check interval keeps the pool from scaling up before Close
+	p := NewAutoScalingPool(0, 1, WithScaleCheckInterval(time.Hour))
+	require.Equal(t, 0, p.RunningWorkers())
+	var done int64
+	for i := 0; i < 5; i++ {
+		require.NoError(t, p.Submit(func() {
+			atomic.AddInt64(&done, 1)
+		}))
+	}
+	require.Equal(t, 5, p.PendingTasks())
+	p.Close()
+	require.Equal(t, int64(5), atomic.LoadInt64(&done))
+	require.Equal(t, 0, p.PendingTasks())
+}
+
+func TestAutoScalingPoolZeroQueueSize(t *testing.T) {
+	p := NewAutoScalingPool(0, 1,
+		WithQueueSize(0),
+		WithScaleUpCooldown(0),
+		WithScaleCheckInterval(5*time.Millisecond),
+	)
+	defer p.Close()
+
+	// Submit does not block forever on an unbuffered queue without workers
+	doneC := make(chan struct{})
+	require.NoError(t, p.Submit(func() {
+		close(doneC)
+	}))
+	select {
+	case <-doneC:
+	case <-time.After(time.Second):
+		t.Fatal("task was not run")
+	}
+}
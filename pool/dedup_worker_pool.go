@@ -0,0 +1,115 @@
+package pool
+
+import "sync"
+
+// DefaultDedupQueueSize is the default capacity of the task queue of DedupWorkerPool.
+const DefaultDedupQueueSize = 1024
+
+// dedupTask is a task queued in DedupWorkerPool with its key.
+type dedupTask[K comparable] struct {
+	key  K
+	task func()
+}
+
+// DedupWorkerPool is a fixed-size worker pool that deduplicates tasks by key.
+// While a task with a key is queued or running, further tasks submitted with the same key are dropped.
+// Once the task has finished, the key may be submitted again.
+type DedupWorkerPool[K comparable] struct {
+	closeMu sync.RWMutex
+	closed  bool
+
+	mu       sync.Mutex
+	inflight map[K]struct{}
+
+	onDropped func(key K)
+	taskC     chan dedupTask[K]
+	wg        sync.WaitGroup
+}
+
+// NewDedupWorkerPool creates a new DedupWorkerPool instance and starts its workers.
+//
+//	params:
+//		- workers: the number of workers. Values less than 1 are treated as 1.
+//		- queueSize: the capacity of the task queue. Negative values are treated as DefaultDedupQueueSize.
+//		- onDropped: an optional callback invoked with the key of each dropped duplicate task.
+func NewDedupWorkerPool[K comparable](workers, queueSize int, onDropped func(key K)) *DedupWorkerPool[K] {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = DefaultDedupQueueSize
+	}
+	p := &DedupWorkerPool[K]{
+		inflight:  make(map[K]struct{}),
+		onDropped: onDropped,
+		taskC:     make(chan dedupTask[K], queueSize),
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// Submit puts a task into the queue, blocking while the queue is full.
+// If a task with the same key is already queued or running, the task is dropped and false is returned.
+// It returns ErrPoolClosed if the pool has been closed.
+func (p *DedupWorkerPool[K]) Submit(key K, task func()) (bool, error) {
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+	if p.closed {
+		return false, ErrPoolClosed
+	}
+
+	p.mu.Lock()
+	if _, ok := p.inflight[key]; ok {
+		p.mu.Unlock()
+		if p.onDropped != nil {
+			p.onDropped(key)
+		}
+		return false, nil
+	}
+	p.inflight[key] = struct{}{}
+	p.mu.Unlock()
+
+	p.taskC <- dedupTask[K]{key: key, task: task}
+	return true, nil
+}
+
+// Inflight returns the number of tasks queued or running.
+func (p *DedupWorkerPool[K]) Inflight() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.inflight)
+}
+
+// Close stops accepting tasks and blocks until the queued tasks are done and all workers have exited.
+func (p *DedupWorkerPool[K]) Close() {
+	p.closeMu.Lock()
+	if p.closed {
+		p.closeMu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.taskC)
+	p.closeMu.Unlock()
+	p.wg.Wait()
+}
+
+// worker runs tasks from the queue until the queue is closed and drained.
+func (p *DedupWorkerPool[K]) worker() {
+	defer p.wg.Done()
+	for t := range p.taskC {
+		p.run(t)
+	}
+}
+
+// run runs the task and releases its key, even if the task panics.
+func (p *DedupWorkerPool[K]) run(t dedupTask[K]) {
+	defer func() {
+		p.mu.Lock()
+		delete(p.inflight, t.key)
+		p.mu.Unlock()
+	}()
+	t.task()
+}
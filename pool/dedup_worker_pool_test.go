@@ -0,0 +1,61 @@
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupWorkerPool(t *testing.T) {
+	var dropped []string
+	p := NewDedupWorkerPool(2, 8, func(key string) {
+		dropped = append(dropped, key)
+	})
+
+	var runs int64
+	releaseC := make(chan struct{})
+	task := func() {
+		<-releaseC
+		atomic.AddInt64(&runs, 1)
+	}
+
+	ok, err := p.Submit("cache:user:1", task)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = p.Submit("cache:user:1", task)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, []string{"cache:user:1"}, dropped)
+	require.Equal(t, 1, p.Inflight())
+
+	close(releaseC)
+	p.Close()
+	require.Equal(t, int64(1), atomic.LoadInt64(&runs))
+	require.Equal(t, 0, p.Inflight())
+
+	_, err = p.Submit("cache:user:1", task)
+	require.ErrorIs(t, err, ErrPoolClosed)
+}
+
+func TestDedupWorkerPoolResubmitAfterDone(t *testing.T) {
+	p := NewDedupWorkerPool[int](1, 0, nil)
+	var runs int64
+	for i := 0; i < 3; i++ {
+		doneC := make(chan struct{})
+		ok, err := p.Submit(1, func() {
+			atomic.AddInt64(&runs, 1)
+			close(doneC)
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+		<-doneC
+		// Wait until the key has been released by the worker.
+		require.Eventually(t, func() bool {
+			return p.Inflight() == 0
+		}, time.Second, time.Millisecond)
+	}
+	p.Close()
+	require.Equal(t, int64(3), atomic.LoadInt64(&runs))
+}
@@ -0,0 +1,85 @@
+package pool
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// DefaultShardQueueSize is the default capacity of the task queue of each worker of ShardedWorkerPool.
+const DefaultShardQueueSize = 256
+
+// ShardedWorkerPool is a fixed-size worker pool in which each worker has a private task queue.
+// Tasks submitted with the same key are always queued to the same worker,
+// so they run serially in submission order, and per-key state needs no locks.
+type ShardedWorkerPool struct {
+	closeMu sync.RWMutex
+	closed  bool
+
+	queues []chan func()
+	wg     sync.WaitGroup
+}
+
+// NewShardedWorkerPool creates a new ShardedWorkerPool instance and starts its workers.
+//
+//	params:
+//		- workers: the number of workers. Values less than 1 are treated as 1.
+//		- queueSize: the capacity of the task queue of each worker. Negative values are treated as DefaultShardQueueSize.
+func NewShardedWorkerPool(workers, queueSize int) *ShardedWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = DefaultShardQueueSize
+	}
+	p := &ShardedWorkerPool{
+		queues: make([]chan func(), workers),
+	}
+	p.wg.Add(workers)
+	for i := range p.queues {
+		p.queues[i] = make(chan func(), queueSize)
+		go p.worker(p.queues[i])
+	}
+	return p
+}
+
+// Submit puts a task into the queue of the worker the key is hashed to, blocking while that queue is full.
+// It returns ErrPoolClosed if the pool has been closed.
+func (p *ShardedWorkerPool) Submit(key string, task func()) error {
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	p.queues[p.shard(key)] <- task
+	return nil
+}
+
+// Close stops accepting tasks and blocks until the queued tasks are done and all workers have exited.
+func (p *ShardedWorkerPool) Close() {
+	p.closeMu.Lock()
+	if p.closed {
+		p.closeMu.Unlock()
+		return
+	}
+	p.closed = true
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.closeMu.Unlock()
+	p.wg.Wait()
+}
+
+// shard returns the index of the worker the key is hashed to.
+func (p *ShardedWorkerPool) shard(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+// worker runs tasks from its queue until the queue is closed and drained.
+func (p *ShardedWorkerPool) worker(queue chan func()) {
+	defer p.wg.Done()
+	for task := range queue {
+		task()
+	}
+}
@@ -0,0 +1,64 @@
+package pool
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedWorkerPool(t *testing.T) {
+	p := NewShardedWorkerPool(4, 2)
+
+	const (
+		keys  = 8
+		tasks = 100
+	)
+	var (
+		mu      sync.Mutex
+		orders  = make(map[string][]int)
+		running [keys]atomic.Int32
+	)
+	for i := 0; i < tasks; i++ {
+		for k := 0; k < keys; k++ {
+			key := fmt.Sprintf("conn:%d", k)
+			require.NoError(t, p.Submit(key, func() {
+				// Tasks sharing a key never run concurrently
+				require.Equal(t, int32(1), running[k].Add(1))
+				defer running[k].Add(-1)
+
+				mu.Lock()
+				orders[key] = append(orders[key], i)
+				mu.Unlock()
+			}))
+		}
+	}
+	p.Close()
+
+	expected := make([]int, tasks)
+	for i := range expected {
+		expected[i] = i
+	}
+	require.Len(t, orders, keys)
+	for key, order := range orders {
+		require.Equal(t, expected, order, key)
+	}
+
+	require.ErrorIs(t, p.Submit("conn:0", func() {}), ErrPoolClosed)
+	p.Close()
+}
+
+func TestShardedWorkerPoolShard(t *testing.T) {
+	p := NewShardedWorkerPool(3, -1)
+	defer p.Close()
+
+	for k := 0; k < 10; k++ {
+		key := fmt.Sprintf("key:%d", k)
+		shard := p.shard(key)
+		require.GreaterOrEqual(t, shard, 0)
+		require.Less(t, shard, 3)
+		require.Equal(t, shard, p.shard(key))
+	}
+}
@@ -0,0 +1,49 @@
+package signal
+
+import (
+	"os"
+	ossignal "os/signal"
+	"sync"
+)
+
+// WatchSignalsMap starts watching the signals in handlers and dispatches each received signal to its handler,
+// in a loop until stopped, so a signal received many times invokes its handler many times.
+// Handlers are invoked one at a time in the watching goroutine. Signals not in handlers are ignored.
+// It returns a stop function which stops watching and waits for the running handler to return.
+func WatchSignalsMap(handlers map[os.Signal]func()) (stop func()) {
+	hs := make(map[os.Signal]func(), len(handlers))
+	signals := make([]os.Signal, 0, len(handlers))
+	for sig, handler := range handlers {
+		hs[sig] = handler
+		signals = append(signals, sig)
+	}
+
+	sigC := make(chan os.Signal, 1)
+	stopC := make(chan struct{})
+	doneC := make(chan struct{})
+	if len(signals) > 0 {
+		ossignal.Notify(sigC, signals...)
+	}
+	go func() {
+		defer close(doneC)
+		for {
+			select {
+			case sig := <-sigC:
+				if handler, ok := hs[sig]; ok && handler != nil {
+					handler()
+				}
+			case <-stopC:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ossignal.Stop(sigC)
+			close(stopC)
+			<-doneC
+		})
+	}
+}
@@ -0,0 +1,42 @@
+//go:build unix
+
+package signal
+
+import (
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchSignalsMap(t *testing.T) {
+	var hupCount, termCount int64
+	stop := WatchSignalsMap(map[os.Signal]func(){
+		syscall.SIGHUP: func() {
+			atomic.AddInt64(&hupCount, 1)
+		},
+		syscall.SIGTERM: func() {
+			atomic.AddInt64(&termCount, 1)
+		},
+	})
+	defer stop()
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&hupCount) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&hupCount) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt64(&termCount) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, int64(2), atomic.LoadInt64(&hupCount))
+}
@@ -3,12 +3,14 @@ package task
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"sync"
 	"time"
 )
 
 var (
-	ErrRegistered = errors.New("registered task type")
+	ErrRegistered    = errors.New("registered task type")
+	ErrInvalidJitter = errors.New("invalid ticker jitter")
 )
 
 var _ Monitor = (*TasksMonitor)(nil)
@@ -31,6 +33,7 @@ type TimerTask struct {
 }
 
 func (t *TimerTask) Run() {
+	defer t.tm.wg.Done()
 	interval := t.triggerTime.Sub(time.Now())
 	if interval < 0 {
 		return
@@ -57,14 +60,27 @@ type TickerTask struct {
 	ticker   *time.Ticker
 	taskType Type
 	interval time.Duration
+	jitter   time.Duration
 	handler  Handler
 }
 
+// nextInterval returns the interval randomized by ±jitter, which is the interval on average.
+func (t *TickerTask) nextInterval() time.Duration {
+	if t.jitter <= 0 {
+		return t.interval
+	}
+	return t.interval + time.Duration(rand.Int63n(int64(2*t.jitter)+1)) - t.jitter
+}
+
 func (t *TickerTask) Run() {
-	t.ticker = time.NewTicker(t.interval)
+	defer t.tm.wg.Done()
+	t.ticker = time.NewTicker(t.nextInterval())
 	for {
 		select {
 		case <-t.ticker.C:
+			if t.jitter > 0 {
+				t.ticker.Reset(t.nextInterval())
+			}
 			t.handler(t.tm.dataStore.GetData(t.taskType))
 		case <-t.tm.ctx.Done():
 			t.ticker.Stop()
@@ -82,6 +98,7 @@ type TasksMonitor struct {
 
 	mu        sync.RWMutex
 	once      sync.Once
+	wg        sync.WaitGroup
 	running   bool
 	timerMap  map[Type]*TimerTask
 	tickerMap map[Type]*TickerTask
@@ -89,6 +106,19 @@ type TasksMonitor struct {
 	exitC chan struct{}
 }
 
+// NewTasksMonitor creates a new TasksMonitor which stops all tasks when ctx is done.
+func NewTasksMonitor(ctx context.Context, store DataStore) *TasksMonitor {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &TasksMonitor{
+		ctx:       ctx,
+		dataStore: store,
+		timerMap:  make(map[Type]*TimerTask),
+		tickerMap: make(map[Type]*TickerTask),
+	}
+}
+
 func (t *TasksMonitor) Start() error {
 	var err error
 	t.once.Do(func() {
@@ -97,9 +127,11 @@ func (t *TasksMonitor) Start() error {
 		t.mu.Lock()
 		defer t.mu.Unlock()
 		for _, task := range t.timerMap {
+			t.wg.Add(1)
 			go task.Run()
 		}
 		for _, task := range t.tickerMap {
+			t.wg.Add(1)
 			go task.Run()
 		}
 		t.running = true
@@ -118,12 +150,24 @@ func (t *TasksMonitor) Stop() error {
 	return nil
 }
 
+// StopAndWait stops the monitor like Stop, then blocks until all running handlers have returned.
+func (t *TasksMonitor) StopAndWait() error {
+	if err := t.Stop(); err != nil {
+		return err
+	}
+	t.wg.Wait()
+	return nil
+}
+
 func (t *TasksMonitor) SetDataStore(store DataStore) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.dataStore = store
 }
 
+// Registered returns whether a timer or a ticker has been registered for the task type.
+// Timers and tickers are registered in separate namespaces,
+// so the same task type may have both a timer and a ticker.
 func (t *TasksMonitor) Registered(taskType Type) bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -134,12 +178,30 @@ func (t *TasksMonitor) Registered(taskType Type) bool {
 	return ok
 }
 
+// RegisteredTimer returns whether a timer has been registered for the task type.
+func (t *TasksMonitor) RegisteredTimer(taskType Type) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.timerMap[taskType]
+	return ok
+}
+
+// RegisteredTicker returns whether a ticker has been registered for the task type.
+func (t *TasksMonitor) RegisteredTicker(taskType Type) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.tickerMap[taskType]
+	return ok
+}
+
+// RegisterTimerForTasks registers a timer for the task type.
+// It returns ErrRegistered only if a timer has already been registered for the task type.
 func (t *TasksMonitor) RegisterTimerForTasks(triggerTime time.Time, taskType Type, handler Handler) error {
-	if t.Registered(taskType) {
-		return ErrRegistered
-	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	if _, ok := t.timerMap[taskType]; ok {
+		return ErrRegistered
+	}
 	newTimer := &TimerTask{
 		tm:          t,
 		taskType:    taskType,
@@ -148,25 +210,41 @@ func (t *TasksMonitor) RegisterTimerForTasks(triggerTime time.Time, taskType Typ
 	}
 	t.timerMap[taskType] = newTimer
 	if t.running {
+		t.wg.Add(1)
 		go newTimer.Run()
 	}
 	return nil
 }
 
+// RegisterTickerForTasks registers a ticker for the task type.
+// It returns ErrRegistered only if a ticker has already been registered for the task type.
 func (t *TasksMonitor) RegisterTickerForTasks(interval time.Duration, taskType Type, handler Handler) error {
-	if t.Registered(taskType) {
-		return ErrRegistered
+	return t.RegisterTickerForTasksWithJitter(interval, 0, taskType, handler)
+}
+
+// RegisterTickerForTasksWithJitter registers a ticker for the task type like RegisterTickerForTasks,
+// but each tick is randomized by ±jitter to spread the load of tickers with the same interval.
+// The interval is still honored on average.
+// It returns ErrInvalidJitter if jitter is negative or not less than interval.
+func (t *TasksMonitor) RegisterTickerForTasksWithJitter(interval, jitter time.Duration, taskType Type, handler Handler) error {
+	if jitter < 0 || (jitter > 0 && jitter >= interval) {
+		return ErrInvalidJitter
 	}
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	if _, ok := t.tickerMap[taskType]; ok {
+		return ErrRegistered
+	}
 	newTicker := &TickerTask{
 		tm:       t,
 		taskType: taskType,
 		interval: interval,
+		jitter:   jitter,
 		handler:  handler,
 	}
 	t.tickerMap[taskType] = newTicker
 	if t.running {
+		t.wg.Add(1)
 		go newTicker.Run()
 	}
 	return nil
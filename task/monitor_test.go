@@ -0,0 +1,122 @@
+package task
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockData struct {
+	id       uint64
+	taskType Type
+}
+
+func (d *mockData) ID() uint64   { return d.id }
+func (d *mockData) Type() Type   { return d.taskType }
+func (d *mockData) Data() []byte { return nil }
+
+type mockDataStore struct{}
+
+func (s *mockDataStore) AddData(Data)                 {}
+func (s *mockDataStore) GetData(dataType Type) Data   { return &mockData{taskType: dataType} }
+func (s *mockDataStore) RemoveData(uint64)            {}
+func (s *mockDataStore) ExistData(dataType Type) bool { return true }
+
+func TestTasksMonitorStopAndWait(t *testing.T) {
+	tm := NewTasksMonitor(context.Background(), &mockDataStore{})
+
+	startedC := make(chan struct{}, 1)
+	var finished atomic.Bool
+	err := tm.RegisterTickerForTasks(10*time.Millisecond, "slow", func(data Data) {
+		select {
+		case startedC <- struct{}{}:
+		default:
+		}
+		time.Sleep(200 * time.Millisecond)
+		finished.Store(true)
+	})
+	require.NoError(t, err)
+	require.NoError(t, tm.Start())
+
+	select {
+	case <-startedC:
+	case <-time.After(time.Second):
+		t.Fatal("handler was not invoked")
+	}
+	require.NoError(t, tm.StopAndWait())
+	require.True(t, finished.Load())
+}
+
+func TestTasksMonitorRegisterTimerAndTickerForSameType(t *testing.T) {
+	var (
+		timerCalls  atomic.Int64
+		tickerCalls atomic.Int64
+	)
+	tm := NewTasksMonitor(context.Background(), &mockDataStore{})
+
+	require.NoError(t, tm.RegisterTimerForTasks(time.Now().Add(10*time.Millisecond), "warmup", func(data Data) {
+		timerCalls.Add(1)
+	}))
+	require.NoError(t, tm.RegisterTickerForTasks(10*time.Millisecond, "warmup", func(data Data) {
+		tickerCalls.Add(1)
+	}))
+	require.True(t, tm.Registered("warmup"))
+	require.True(t, tm.RegisteredTimer("warmup"))
+	require.True(t, tm.RegisteredTicker("warmup"))
+	require.False(t, tm.RegisteredTimer("other"))
+
+	// Duplicates within the same kind are still rejected
+	require.ErrorIs(t, tm.RegisterTimerForTasks(time.Now(), "warmup", func(data Data) {}), ErrRegistered)
+	require.ErrorIs(t, tm.RegisterTickerForTasks(time.Second, "warmup", func(data Data) {}), ErrRegistered)
+
+	require.NoError(t, tm.Start())
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, tm.StopAndWait())
+
+	require.Equal(t, int64(1), timerCalls.Load())
+	require.GreaterOrEqual(t, tickerCalls.Load(), int64(1))
+}
+
+func TestTickerTaskNextIntervalWithJitter(t *testing.T) {
+	task := &TickerTask{interval: 100 * time.Millisecond, jitter: 20 * time.Millisecond}
+
+	var (
+		sum      time.Duration
+		distinct = make(map[time.Duration]struct{})
+	)
+	const n = 1000
+	for i := 0; i < n; i++ {
+		interval := task.nextInterval()
+		require.GreaterOrEqual(t, interval, 80*time.Millisecond)
+		require.LessOrEqual(t, interval, 120*time.Millisecond)
+		sum += interval
+		distinct[interval] = struct{}{}
+	}
+	require.Greater(t, len(distinct), 1)
+	require.InDelta(t, float64(100*time.Millisecond), float64(sum/n), float64(5*time.Millisecond))
+
+	task.jitter = 0
+	require.Equal(t, 100*time.Millisecond, task.nextInterval())
+}
+
+func TestTasksMonitorRegisterTickerForTasksWithJitter(t *testing.T) {
+	tm := NewTasksMonitor(context.Background(), &mockDataStore{})
+
+	require.ErrorIs(t, tm.RegisterTickerForTasksWithJitter(time.Second, -time.Millisecond, "jitter", func(data Data) {}), ErrInvalidJitter)
+	require.ErrorIs(t, tm.RegisterTickerForTasksWithJitter(time.Second, time.Second, "jitter", func(data Data) {}), ErrInvalidJitter)
+	require.False(t, tm.RegisteredTicker("jitter"))
+
+	var calls atomic.Int64
+	require.NoError(t, tm.RegisterTickerForTasksWithJitter(10*time.Millisecond, 5*time.Millisecond, "jitter", func(data Data) {
+		calls.Add(1)
+	}))
+	require.ErrorIs(t, tm.RegisterTickerForTasks(time.Second, "jitter", func(data Data) {}), ErrRegistered)
+
+	require.NoError(t, tm.Start())
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, tm.StopAndWait())
+	require.GreaterOrEqual(t, calls.Load(), int64(3))
+}
@@ -0,0 +1,83 @@
+package types
+
+import "sync"
+
+// BiMap represents a thread-safe bidirectional map which keeps a one-to-one mapping between keys and values,
+// so that entries can be looked up either by key or by value.
+type BiMap[K comparable, V comparable] struct {
+	mu       sync.RWMutex
+	forward  map[K]V
+	backward map[V]K
+}
+
+// NewBiMap creates a new instance of the BiMap data structure.
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward:  make(map[K]V),
+		backward: make(map[V]K),
+	}
+}
+
+// Put binds the key and the value to each other.
+// Any existing mapping of the key or of the value is removed first to keep both directions consistent.
+func (m *BiMap[K, V]) Put(k K, v V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if oldV, ok := m.forward[k]; ok {
+		delete(m.backward, oldV)
+	}
+	if oldK, ok := m.backward[v]; ok {
+		delete(m.forward, oldK)
+	}
+	m.forward[k] = v
+	m.backward[v] = k
+}
+
+// GetByKey returns the value bound to the key and a boolean indicating whether the key exists.
+func (m *BiMap[K, V]) GetByKey(k K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.forward[k]
+	return v, ok
+}
+
+// GetByValue returns the key bound to the value and a boolean indicating whether the value exists.
+func (m *BiMap[K, V]) GetByValue(v V) (K, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	k, ok := m.backward[v]
+	return k, ok
+}
+
+// DeleteByKey removes the mapping of the key.
+// It returns a boolean indicating whether the key existed.
+func (m *BiMap[K, V]) DeleteByKey(k K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.forward[k]
+	if ok {
+		delete(m.forward, k)
+		delete(m.backward, v)
+	}
+	return ok
+}
+
+// DeleteByValue removes the mapping of the value.
+// It returns a boolean indicating whether the value existed.
+func (m *BiMap[K, V]) DeleteByValue(v V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k, ok := m.backward[v]
+	if ok {
+		delete(m.backward, v)
+		delete(m.forward, k)
+	}
+	return ok
+}
+
+// Len returns the current number of mappings in the BiMap.
+func (m *BiMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.forward)
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBiMap(t *testing.T) {
+	t.Parallel()
+
+	m := NewBiMap[int, string]()
+	m.Put(1, "a")
+	m.Put(2, "b")
+	require.Equal(t, 2, m.Len())
+
+	v, ok := m.GetByKey(1)
+	require.True(t, ok)
+	require.Equal(t, "a", v)
+	k, ok := m.GetByValue("b")
+	require.True(t, ok)
+	require.Equal(t, 2, k)
+
+	// Re-binding value "a" to key 3 removes the stale mapping of key 1.
+	m.Put(3, "a")
+	_, ok = m.GetByKey(1)
+	require.False(t, ok)
+	k, ok = m.GetByValue("a")
+	require.True(t, ok)
+	require.Equal(t, 3, k)
+	require.Equal(t, 2, m.Len())
+
+	// Re-binding key 2 to value "c" removes the stale mapping of value "b".
+	m.Put(2, "c")
+	_, ok = m.GetByValue("b")
+	require.False(t, ok)
+	require.Equal(t, 2, m.Len())
+
+	require.True(t, m.DeleteByKey(3))
+	require.False(t, m.DeleteByKey(3))
+	_, ok = m.GetByValue("a")
+	require.False(t, ok)
+
+	require.True(t, m.DeleteByValue("c"))
+	require.False(t, m.DeleteByValue("c"))
+	_, ok = m.GetByKey(2)
+	require.False(t, ok)
+	require.Equal(t, 0, m.Len())
+}
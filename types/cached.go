@@ -0,0 +1,69 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// Cached holds a value produced by a loader and refreshes it on access once the TTL has elapsed.
+// It is thread-safe, and concurrent accesses wait for a single refresh instead of loading in parallel.
+type Cached[T any] struct {
+	mu       sync.RWMutex
+	ttl      time.Duration
+	loader   func() (T, error)
+	value    T
+	loaded   bool
+	loadedAt time.Time
+
+	now func() time.Time
+}
+
+// NewCached creates a new Cached instance.
+// The loader is not invoked until the first Get. If ttl <= 0, the value never expires once loaded.
+func NewCached[T any](ttl time.Duration, loader func() (T, error)) *Cached[T] {
+	return &Cached[T]{
+		ttl:    ttl,
+		loader: loader,
+		now:    time.Now,
+	}
+}
+
+// Get returns the current value, invoking the loader first if the value has not been loaded or has expired.
+// If the loader fails, the error is returned with the zero value, and the next Get invokes the loader again.
+func (c *Cached[T]) Get() (T, error) {
+	c.mu.RLock()
+	if c.fresh() {
+		v := c.value
+		c.mu.RUnlock()
+		return v, nil
+	}
+	c.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Another caller may have refreshed the value while waiting for the lock
+	if c.fresh() {
+		return c.value, nil
+	}
+	v, err := c.loader()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	c.value = v
+	c.loaded = true
+	c.loadedAt = c.now()
+	return v, nil
+}
+
+// Invalidate marks the value as expired, so that the next Get invokes the loader.
+func (c *Cached[T]) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loaded = false
+}
+
+// fresh reports whether the value is loaded and not expired, the caller must hold the lock.
+func (c *Cached[T]) fresh() bool {
+	return c.loaded && (c.ttl <= 0 || c.now().Sub(c.loadedAt) < c.ttl)
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCached(t *testing.T) {
+	t.Parallel()
+
+	var calls int64
+	c := NewCached(time.Minute, func() (int64, error) {
+		return atomic.AddInt64(&calls, 1), nil
+	})
+	now := time.Now()
+	c.now = func() time.Time {
+		return now
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Get()
+			require.NoError(t, err)
+			require.Equal(t, int64(1), v)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int64(1), atomic.LoadInt64(&calls))
+
+	now = now.Add(59 * time.Second)
+	v, err := c.Get()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), v)
+
+	now = now.Add(time.Second)
+	v, err = c.Get()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), v)
+
+	c.Invalidate()
+	v, err = c.Get()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), v)
+}
+
+func TestCachedLoaderError(t *testing.T) {
+	t.Parallel()
+
+	fail := true
+	c := NewCached(time.Minute, func() (string, error) {
+		if fail {
+			return "", errors.New("load failed")
+		}
+		return "ok", nil
+	})
+
+	_, err := c.Get()
+	require.Error(t, err)
+
+	fail = false
+	v, err := c.Get()
+	require.NoError(t, err)
+	require.Equal(t, "ok", v)
+}
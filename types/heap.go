@@ -0,0 +1,65 @@
+package types
+
+import "container/heap"
+
+// Heap represents a priority queue of elements of type T ordered by a less function.
+// The element for which less reports true against all others is popped first,
+// so a less of `a < b` makes a min-heap and `a > b` makes a max-heap.
+// Heap is not safe for concurrent use.
+type Heap[T any] struct {
+	h *heapSlice[T]
+}
+
+// heapSlice implements heap.Interface for Heap.
+type heapSlice[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *heapSlice[T]) Len() int           { return len(h.items) }
+func (h *heapSlice[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *heapSlice[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *heapSlice[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+func (h *heapSlice[T]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	var zero T
+	h.items[n-1] = zero
+	h.items = h.items[:n-1]
+	return item
+}
+
+// NewHeap creates a new instance of the Heap data structure ordered by the given less function.
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{h: &heapSlice[T]{less: less}}
+}
+
+// Push adds an element to the heap.
+func (h *Heap[T]) Push(v T) {
+	heap.Push(h.h, v)
+}
+
+// Pop removes the top element from the heap.
+// It returns the removed element and a boolean indicating whether the heap was not empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	if h.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(h.h).(T), true
+}
+
+// Peek returns the top element of the heap without removing it.
+// It returns the element and a boolean indicating whether the heap was not empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if h.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.h.items[0], true
+}
+
+// Len returns the current number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return h.h.Len()
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeap(t *testing.T) {
+	t.Parallel()
+
+	minHeap := NewHeap(func(a, b int) bool { return a < b })
+	maxHeap := NewHeap(func(a, b int) bool { return a > b })
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		minHeap.Push(v)
+		maxHeap.Push(v)
+	}
+	require.Equal(t, 5, minHeap.Len())
+
+	top, ok := minHeap.Peek()
+	require.True(t, ok)
+	require.Equal(t, 1, top)
+	require.Equal(t, 5, minHeap.Len())
+
+	res1 := make([]int, 0, 5)
+	res2 := make([]int, 0, 5)
+	for i := 0; i < 5; i++ {
+		v, _ := minHeap.Pop()
+		res1 = append(res1, v)
+		v, _ = maxHeap.Pop()
+		res2 = append(res2, v)
+	}
+	require.Equal(t, []int{1, 2, 3, 4, 5}, res1)
+	require.Equal(t, []int{5, 4, 3, 2, 1}, res2)
+
+	_, ok = minHeap.Pop()
+	require.False(t, ok)
+	_, ok = minHeap.Peek()
+	require.False(t, ok)
+}
@@ -4,3 +4,10 @@ package types
 type Clonable[T any] interface {
 	Clone() T
 }
+
+// Number defines a constraint of the integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
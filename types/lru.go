@@ -0,0 +1,100 @@
+package types
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRU represents a thread-safe least-recently-used cache with a fixed capacity.
+// When a new key is put into a full LRU, the least recently used entry is evicted.
+type LRU[K comparable, V any] struct {
+	mu        sync.Mutex
+	capacity  int
+	ll        *list.List
+	items     map[K]*list.Element
+	onEvicted func(k K, v V)
+}
+
+// lruEntry represents a single entry of the LRU.
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU creates a new instance of the LRU data structure.
+//
+//	params:
+//		- capacity: the max number of entries kept in the LRU. Values less than 1 are treated as 1.
+//		- onEvicted: an optional callback invoked with each entry evicted because the LRU is full.
+//		  It is invoked after the lock of the LRU has been released, so it may call methods of the LRU.
+func NewLRU[K comparable, V any](capacity int, onEvicted func(k K, v V)) *LRU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRU[K, V]{
+		capacity:  capacity,
+		ll:        list.New(),
+		items:     make(map[K]*list.Element),
+		onEvicted: onEvicted,
+	}
+}
+
+// Get returns the value of the key and a boolean indicating whether the key exists.
+// A hit marks the entry as the most recently used.
+func (c *LRU[K, V]) Get(k K) (v V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ele, ok := c.items[k]
+	if !ok {
+		return v, false
+	}
+	c.ll.MoveToFront(ele)
+	return ele.Value.(*lruEntry[K, V]).value, true
+}
+
+// Put sets the value of the key and marks the entry as the most recently used.
+// If the LRU is full, the least recently used entry is evicted.
+func (c *LRU[K, V]) Put(k K, v V) {
+	c.mu.Lock()
+	if ele, ok := c.items[k]; ok {
+		ele.Value.(*lruEntry[K, V]).value = v
+		c.ll.MoveToFront(ele)
+		c.mu.Unlock()
+		return
+	}
+	c.items[k] = c.ll.PushFront(&lruEntry[K, V]{key: k, value: v})
+	var evicted *lruEntry[K, V]
+	if c.ll.Len() > c.capacity {
+		back := c.ll.Back()
+		evicted = back.Value.(*lruEntry[K, V])
+		c.ll.Remove(back)
+		delete(c.items, evicted.key)
+	}
+	onEvicted := c.onEvicted
+	c.mu.Unlock()
+
+	if evicted != nil && onEvicted != nil {
+		onEvicted(evicted.key, evicted.value)
+	}
+}
+
+// Remove removes the entry of the key without invoking the eviction callback.
+// It returns a boolean indicating whether the key existed.
+func (c *LRU[K, V]) Remove(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ele, ok := c.items[k]
+	if !ok {
+		return false
+	}
+	c.ll.Remove(ele)
+	delete(c.items, k)
+	return true
+}
+
+// Len returns the number of entries in the LRU.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	t.Parallel()
+
+	var evicted []int
+	c := NewLRU[int, string](3, func(k int, v string) {
+		evicted = append(evicted, k)
+	})
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Put(3, "c")
+
+	// Touch 1 so that 2 becomes the least recently used.
+	v, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "a", v)
+
+	c.Put(4, "d")
+	require.Equal(t, []int{2}, evicted)
+	_, ok = c.Get(2)
+	require.False(t, ok)
+
+	// Overwriting an existing key refreshes it without evicting.
+	c.Put(3, "cc")
+	require.Equal(t, 3, c.Len())
+	c.Put(5, "e")
+	require.Equal(t, []int{2, 1}, evicted)
+	v, ok = c.Get(3)
+	require.True(t, ok)
+	require.Equal(t, "cc", v)
+
+	require.True(t, c.Remove(3))
+	require.False(t, c.Remove(3))
+	require.Equal(t, 2, c.Len())
+	require.Equal(t, []int{2, 1}, evicted)
+}
+
+func TestLRUConcurrent(t *testing.T) {
+	t.Parallel()
+
+	c := NewLRU[int, int](64, func(k int, v int) {})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := (g*1000 + i) % 128
+				c.Put(k, i)
+				c.Get(k)
+				if i%10 == 0 {
+					c.Remove(k)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	require.LessOrEqual(t, c.Len(), 64)
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"container/list"
+	"sync"
+)
+
+// OrderedSet represents a thread-safe set data structure that stores unique elements of type T
+// and preserves the order in which they were added.
+type OrderedSet[T comparable] struct {
+	mu    sync.RWMutex
+	ll    *list.List
+	items map[T]*list.Element
+}
+
+// NewOrderedSet creates a new instance of the OrderedSet data structure.
+func NewOrderedSet[T comparable]() *OrderedSet[T] {
+	return &OrderedSet[T]{
+		ll:    list.New(),
+		items: make(map[T]*list.Element),
+	}
+}
+
+// Add appends an element to the set.
+// It returns a boolean indicating whether the element was added successfully (true if added, false if already exists).
+// Adding an existing element does not change its position.
+func (s *OrderedSet[T]) Add(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[v]; ok {
+		return false
+	}
+	s.items[v] = s.ll.PushBack(v)
+	return true
+}
+
+// Remove removes an element from the set.
+// It returns a boolean indicating whether the element was successfully removed (true if removed, false if not found).
+func (s *OrderedSet[T]) Remove(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ele, ok := s.items[v]
+	if !ok {
+		return false
+	}
+	s.ll.Remove(ele)
+	delete(s.items, v)
+	return true
+}
+
+// Contains checks if an element exists in the set.
+func (s *OrderedSet[T]) Contains(v T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.items[v]
+	return ok
+}
+
+// Len returns the current size of the set.
+func (s *OrderedSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Slice returns all elements in the set in the order they were added.
+func (s *OrderedSet[T]) Slice() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]T, 0, len(s.items))
+	for ele := s.ll.Front(); ele != nil; ele = ele.Next() {
+		result = append(result, ele.Value.(T))
+	}
+	return result
+}
+
+// Range iterates over all elements in the set in the order they were added and calls the provided function for each element.
+// It stops iteration if the function returns false.
+// The set is read-locked during the iteration, so the function must not modify the set.
+func (s *OrderedSet[T]) Range(f func(t T) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for ele := s.ll.Front(); ele != nil; ele = ele.Next() {
+		if !f(ele.Value.(T)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedSet(t *testing.T) {
+	t.Parallel()
+
+	s := NewOrderedSet[string]()
+	require.Empty(t, s.Slice())
+
+	for _, v := range []string{"c", "a", "b", "a", "d", "c"} {
+		s.Add(v)
+	}
+	require.Equal(t, []string{"c", "a", "b", "d"}, s.Slice())
+	require.Equal(t, 4, s.Len())
+	require.False(t, s.Add("b"))
+
+	require.True(t, s.Remove("a"))
+	require.False(t, s.Remove("a"))
+	require.False(t, s.Contains("a"))
+	require.True(t, s.Contains("b"))
+	require.Equal(t, []string{"c", "b", "d"}, s.Slice())
+
+	// A re-added element goes to the end
+	require.True(t, s.Add("a"))
+	require.Equal(t, []string{"c", "b", "d", "a"}, s.Slice())
+	require.Equal(t, 4, s.Len())
+
+	visited := make([]string, 0)
+	s.Range(func(v string) bool {
+		visited = append(visited, v)
+		return v != "d"
+	})
+	require.Equal(t, []string{"c", "b", "d"}, visited)
+}
@@ -5,3 +5,10 @@ type Entry[K comparable, V any] struct {
 	Key   K
 	Value V
 }
+
+// Tuple3 defines a group of three values.
+type Tuple3[A any, B any, C any] struct {
+	A A
+	B B
+	C C
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// BatchProcessor accumulates items and flushes them in batches,
+// whenever the batch reaches maxSize or maxWait has elapsed since the first item of the batch was added,
+// whichever comes first.
+type BatchProcessor[T any] struct {
+	mu      sync.Mutex
+	maxSize int
+	maxWait time.Duration
+	flush   func([]T)
+
+	items  []T
+	timer  *time.Timer
+	gen    uint64
+	closed bool
+}
+
+// NewBatchProcessor creates a new BatchProcessor instance.
+//
+//	params:
+//		- maxSize: the max number of items in a batch. If maxSize <= 0, batches are flushed by time only.
+//		- maxWait: the max time an item waits before being flushed. If maxWait <= 0, batches are flushed by size only.
+//		- flush: the function invoked with each batch. Batches are flushed one at a time,
+//			so flush must not call Add or Close.
+func NewBatchProcessor[T any](maxSize int, maxWait time.Duration, flush func([]T)) *BatchProcessor[T] {
+	return &BatchProcessor[T]{
+		maxSize: maxSize,
+		maxWait: maxWait,
+		flush:   flush,
+	}
+}
+
+// Add adds an item to the current batch, flushing the batch if it reaches max size.
+// Items added after Close are dropped.
+func (b *BatchProcessor[T]) Add(item T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.items = append(b.items, item)
+	if b.maxSize > 0 && len(b.items) >= b.maxSize {
+		b.flushLocked()
+		return
+	}
+	if len(b.items) == 1 && b.maxWait > 0 {
+		gen := b.gen
+		b.timer = time.AfterFunc(b.maxWait, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			// The batch has been flushed already, ignore the stale timer.
+			if gen != b.gen {
+				return
+			}
+			b.flushLocked()
+		})
+	}
+}
+
+// Close flushes the remaining items and stops the processor.
+func (b *BatchProcessor[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	b.flushLocked()
+}
+
+// flushLocked flushes the current batch, the caller must hold the lock.
+func (b *BatchProcessor[T]) flushLocked() {
+	b.gen++
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.items) == 0 {
+		return
+	}
+	items := b.items
+	b.items = nil
+	b.flush(items)
+}
@@ -0,0 +1,60 @@
+package util
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *batchRecorder) flush(items []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, items)
+}
+
+func (r *batchRecorder) get() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches
+}
+
+func TestBatchProcessorFlushBySize(t *testing.T) {
+	t.Parallel()
+
+	r := &batchRecorder{}
+	b := NewBatchProcessor(3, time.Hour, r.flush)
+	for i := 1; i <= 7; i++ {
+		b.Add(i)
+	}
+	require.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}}, r.get())
+
+	b.Close()
+	require.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, r.get())
+
+	b.Add(8)
+	b.Close()
+	require.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, r.get())
+}
+
+func TestBatchProcessorFlushByTime(t *testing.T) {
+	t.Parallel()
+
+	r := &batchRecorder{}
+	b := NewBatchProcessor(10, 50*time.Millisecond, r.flush)
+	defer b.Close()
+	b.Add(1)
+	b.Add(2)
+	require.Empty(t, r.get())
+
+	require.Eventually(t, func() bool {
+		return len(r.get()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, [][]int{{1, 2}}, r.get())
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// BloomFilter is a thread-safe probabilistic set for membership tests with bounded memory.
+// Test never reports false for an added item, but may report true for an item never added.
+type BloomFilter struct {
+	mu     sync.RWMutex
+	bits   []uint64
+	m      uint64
+	hashes uint64
+}
+
+// NewBloomFilter creates a new BloomFilter sized for the expected number of items and the false positive rate.
+// Non-positive expectedN is treated as 1, and falsePositiveRate out of (0, 1) is treated as 0.01.
+func NewBloomFilter(expectedN int, falsePositiveRate float64) *BloomFilter {
+	if expectedN <= 0 {
+		expectedN = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+	n := float64(expectedN)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+	return &BloomFilter{
+		bits:   make([]uint64, (uint64(m)+63)/64),
+		m:      uint64(m),
+		hashes: uint64(k),
+	}
+}
+
+// Add adds an item to the filter.
+func (f *BloomFilter) Add(data []byte) {
+	h1, h2 := bloomHashes(data)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := uint64(0); i < f.hashes; i++ {
+		pos := (h1 + i*h2) % f.m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// Test returns true if the item may have been added, or false if it has definitely not been added.
+func (f *BloomFilter) Test(data []byte) bool {
+	h1, h2 := bloomHashes(data)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for i := uint64(0); i < f.hashes; i++ {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes returns the two base hashes used to derive the k hash positions (Kirsch-Mitzenmacher).
+func bloomHashes(data []byte) (uint64, uint64) {
+	h1 := fnv.New64a()
+	_, _ = h1.Write(data)
+	h2 := fnv.New64()
+	_, _ = h2.Write(data)
+	// Make sure the step is odd so that positions don't collapse onto a few bits.
+	return h1.Sum64(), h2.Sum64() | 1
+}
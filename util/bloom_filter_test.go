@@ -0,0 +1,30 @@
+package util
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	t.Parallel()
+
+	n := 10000
+	rate := 0.01
+	f := NewBloomFilter(n, rate)
+	for i := 0; i < n; i++ {
+		f.Add([]byte("item-" + strconv.Itoa(i)))
+	}
+	for i := 0; i < n; i++ {
+		require.True(t, f.Test([]byte("item-"+strconv.Itoa(i))))
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if f.Test([]byte("other-" + strconv.Itoa(i))) {
+			falsePositives++
+		}
+	}
+	require.Less(t, float64(falsePositives)/float64(n), rate*3)
+}
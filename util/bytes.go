@@ -3,6 +3,7 @@ package util
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/gob"
 )
 
 // Uint64ToBytes converts a uint64 value to a byte slice.
@@ -100,3 +101,40 @@ func BytesToInt(b []byte) int {
 	_ = binary.Read(bytesBuffer, binary.BigEndian, &x)
 	return int(x)
 }
+
+// StructToBytes serializes v to a byte slice using encoding/gob.
+// Only exported fields are serialized, and a struct without any exported field can not be encoded.
+// Concrete types stored in interface fields must be registered with gob.Register before encoding and decoding.
+func StructToBytes(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// BytesToStruct deserializes a byte slice produced by StructToBytes to a value of type T using encoding/gob.
+// Fields are matched by name, so unexported fields of T are left zero.
+func BytesToStruct[T any](data []byte) (T, error) {
+	var v T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+	return v, err
+}
+
+// ChunkBytes splits data into chunks of at most chunkSize bytes, e.g. to align writes with a file size limit.
+// The chunks are views of data, not copies, and their capacity is capped to their length,
+// so appending to a chunk does not overwrite the next one. It panics if chunkSize <= 0, like SliceCutChunks.
+func ChunkBytes(data []byte, chunkSize int) [][]byte {
+	if chunkSize <= 0 {
+		panic("Size parameter must be greater than 0")
+	}
+	result := make([][]byte, 0, (len(data)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(data); start += chunkSize {
+		end := start + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		result = append(result, data[start:end:end])
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type gobShape interface {
+	Area() float64
+}
+
+type gobSquare struct {
+	Side float64
+}
+
+func (s gobSquare) Area() float64 {
+	return s.Side * s.Side
+}
+
+func TestStructToBytesAndBytesToStruct(t *testing.T) {
+	t.Parallel()
+
+	gob.Register(gobSquare{})
+
+	type nested struct {
+		Name string
+	}
+	type record struct {
+		ID       uint64
+		Score    float64
+		Enabled  bool
+		Tags     []string
+		Attrs    map[string]int
+		Nested   *nested
+		Created  time.Time
+		Shape    gobShape
+		internal int
+	}
+
+	in := record{
+		ID:       42,
+		Score:    3.14,
+		Enabled:  true,
+		Tags:     []string{"a", "b"},
+		Attrs:    map[string]int{"x": 1},
+		Nested:   &nested{Name: "n"},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Shape:    gobSquare{Side: 2},
+		internal: 7,
+	}
+	bz, err := StructToBytes(in)
+	require.NoError(t, err)
+
+	out, err := BytesToStruct[record](bz)
+	require.NoError(t, err)
+	require.Equal(t, 0, out.internal)
+	out.internal = in.internal
+	require.Equal(t, in, out)
+	require.Equal(t, 4.0, out.Shape.Area())
+
+	_, err = BytesToStruct[record]([]byte("invalid"))
+	require.Error(t, err)
+}
+
+func TestChunkBytes(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("0123456789")
+	chunks := ChunkBytes(data, 3)
+	lengths := SliceTransformType(chunks, func(index int, item []byte) int {
+		return len(item)
+	})
+	require.Equal(t, []int{3, 3, 3, 1}, lengths)
+	require.Equal(t, data, bytes.Join(chunks, nil))
+
+	// Chunks are views of data
+	chunks[0][0] = 'x'
+	require.Equal(t, byte('x'), data[0])
+	_ = append(chunks[0], 'y')
+	require.Equal(t, byte('3'), data[3])
+
+	require.Empty(t, ChunkBytes(nil, 3))
+	require.Panics(t, func() {
+		ChunkBytes(data, 0)
+	})
+}
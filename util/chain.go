@@ -0,0 +1,65 @@
+package util
+
+// SliceChain is a fluent builder composing filter and map operations over a slice.
+// Operations are composed lazily and applied in a single pass by the terminal Collect or Reduce,
+// so no intermediate slice is allocated.
+type SliceChain[T any] struct {
+	collection []T
+	steps      []func(item T) (T, bool)
+}
+
+// Chain creates a SliceChain over the collection.
+func Chain[T any](collection []T) *SliceChain[T] {
+	return &SliceChain[T]{collection: collection}
+}
+
+// Filter appends a step keeping only the elements predicate returns truthy for.
+func (c *SliceChain[T]) Filter(predicate func(item T) bool) *SliceChain[T] {
+	c.steps = append(c.steps, func(item T) (T, bool) {
+		return item, predicate(item)
+	})
+	return c
+}
+
+// Map appends a step transforming each element with the transformer.
+func (c *SliceChain[T]) Map(transformer func(item T) T) *SliceChain[T] {
+	c.steps = append(c.steps, func(item T) (T, bool) {
+		return transformer(item), true
+	})
+	return c
+}
+
+// each runs all steps on each element and calls fn with the elements surviving all of them.
+func (c *SliceChain[T]) each(fn func(item T)) {
+	for _, item := range c.collection {
+		ok := true
+		for _, step := range c.steps {
+			if item, ok = step(item); !ok {
+				break
+			}
+		}
+		if ok {
+			fn(item)
+		}
+	}
+}
+
+// Collect runs the chain and returns the resulting elements as a new slice.
+func (c *SliceChain[T]) Collect() []T {
+	result := make([]T, 0, len(c.collection))
+	c.each(func(item T) {
+		result = append(result, item)
+	})
+	return result
+}
+
+// Reduce runs the chain and reduces the resulting elements to a value like SliceReduce,
+// where index is the position of the element in the chain output.
+func (c *SliceChain[T]) Reduce(accumulator func(agg T, item T, index int) T, initial T) T {
+	index := 0
+	c.each(func(item T) {
+		initial = accumulator(initial, item, index)
+		index++
+	})
+	return initial
+}
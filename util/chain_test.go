@@ -0,0 +1,39 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	collection := []int{1, 2, 3, 4, 5, 6}
+	isEven := func(item int) bool {
+		return item%2 == 0
+	}
+	square := func(item int) int {
+		return item * item
+	}
+
+	res1 := Chain(collection).Filter(isEven).Map(square).Collect()
+	expected := SliceTransformType(SliceFilter(collection, func(_ int, item int) bool {
+		return isEven(item)
+	}), func(_ int, item int) int {
+		return square(item)
+	})
+	require.Equal(t, expected, res1)
+	require.Equal(t, []int{4, 16, 36}, res1)
+
+	res2 := Chain(collection).Map(square).Filter(isEven).Reduce(func(agg int, item int, _ int) int {
+		return agg + item
+	}, 0)
+	require.Equal(t, 56, res2)
+
+	res3 := Chain([]int{}).Filter(isEven).Collect()
+	require.Equal(t, []int{}, res3)
+
+	res4 := Chain(collection).Collect()
+	require.Equal(t, collection, res4)
+}
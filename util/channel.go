@@ -0,0 +1,79 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// FanIn merges the values received from all channels into the returned channel.
+// The order of values across channels is unspecified, while the values of a single channel keep their order.
+// The returned channel is closed once all channels have been closed.
+func FanIn[T any](channels ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(channels))
+	for _, c := range channels {
+		go func(c <-chan T) {
+			defer wg.Done()
+			for v := range c {
+				out <- v
+			}
+		}(c)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut broadcasts each value received from in to all n returned channels, so every channel receives every value.
+// A value is sent to the channels one by one, so the broadcast proceeds at the pace of the slowest consumer.
+// The returned channels are closed once in has been closed. If n <= 0, an empty slice is returned and in is not read.
+func FanOut[T any](in <-chan T, n int) []<-chan T {
+	if n <= 0 {
+		return []<-chan T{}
+	}
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for v := range in {
+			for _, out := range outs {
+				out <- v
+			}
+		}
+	}()
+	return result
+}
+
+// DrainN reads up to n values from ch and returns them in the order received.
+// It stops early when timeout elapses since the call or when ch is closed, returning the values read so far.
+func DrainN[T any](ch <-chan T, n int, timeout time.Duration) []T {
+	if n <= 0 {
+		return []T{}
+	}
+	result := make([]T, 0, n)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for len(result) < n {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return result
+			}
+			result = append(result, v)
+		case <-timer.C:
+			return result
+		}
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+package util
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFanIn(t *testing.T) {
+	t.Parallel()
+
+	c1 := make(chan int)
+	c2 := make(chan int)
+	go func() {
+		defer close(c1)
+		for i := 0; i < 5; i++ {
+			c1 <- i
+		}
+	}()
+	go func() {
+		defer close(c2)
+		for i := 5; i < 10; i++ {
+			c2 <- i
+		}
+	}()
+
+	var res []int
+	for v := range FanIn[int](c1, c2) {
+		res = append(res, v)
+	}
+	sort.Ints(res)
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, res)
+
+	_, ok := <-FanIn[int]()
+	require.False(t, ok)
+}
+
+func TestFanOut(t *testing.T) {
+	t.Parallel()
+
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < 5; i++ {
+			in <- i
+		}
+	}()
+
+	outs := FanOut(in, 2)
+	require.Len(t, outs, 2)
+	results := make([][]int, len(outs))
+	done := make(chan struct{})
+	for i, out := range outs {
+		go func(i int, out <-chan int) {
+			defer func() { done <- struct{}{} }()
+			for v := range out {
+				results[i] = append(results[i], v)
+			}
+		}(i, out)
+	}
+	for range outs {
+		<-done
+	}
+	require.Equal(t, []int{0, 1, 2, 3, 4}, results[0])
+	require.Equal(t, []int{0, 1, 2, 3, 4}, results[1])
+
+	require.Empty(t, FanOut(in, 0))
+}
+
+func TestDrainN(t *testing.T) {
+	t.Parallel()
+
+	// Fast channel
+	fast := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		fast <- i
+	}
+	require.Equal(t, []int{0, 1, 2}, DrainN(fast, 3, time.Second))
+
+	// Slow channel
+	slow := make(chan int)
+	go func() {
+		slow <- 1
+		time.Sleep(time.Second)
+		slow <- 2
+	}()
+	start := time.Now()
+	require.Equal(t, []int{1}, DrainN(slow, 2, 50*time.Millisecond))
+	require.Less(t, time.Since(start), time.Second)
+
+	// Closed channel
+	closed := make(chan int, 1)
+	closed <- 1
+	close(closed)
+	require.Equal(t, []int{1}, DrainN(closed, 5, time.Second))
+
+	require.Equal(t, []int{}, DrainN(fast, 0, time.Second))
+}
@@ -0,0 +1,158 @@
+package util
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Execute when the call is rejected because the circuit is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState uint8
+
+const (
+	// CircuitClosed lets all calls through and counts consecutive failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all calls until the reset timeout has elapsed.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe call through to check whether the downstream has recovered.
+	CircuitHalfOpen
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker protects a downstream by failing fast after repeated failures.
+// It opens after failureThreshold consecutive failures, rejects calls while open,
+// and half-opens after resetTimeout to let a probe call through.
+// A successful probe closes the circuit, a failed probe opens it again.
+// It is safe for concurrent use.
+type CircuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	resetTimeout     time.Duration
+	state            CircuitState
+	failures         int
+	openedAt         time.Time
+	probing          bool
+
+	now func() time.Time
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker instance in the closed state.
+//
+//	params:
+//		- failureThreshold: the number of consecutive failures that opens the circuit. Values less than 1 are treated as 1.
+//		- resetTimeout: the time the circuit stays open before half-opening.
+func NewCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+		now:              time.Now,
+	}
+}
+
+// Execute calls fn if the circuit allows it and records its result.
+// It returns ErrCircuitOpen without calling fn if the circuit is open,
+// or if it is half-open and a probe call is already in flight. Otherwise, it returns the error of fn.
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	probe, err := cb.before()
+	if err != nil {
+		return err
+	}
+	success := false
+	// A panic of fn is recorded as a failure before it propagates.
+	defer func() {
+		cb.after(probe, success)
+	}()
+	err = fn()
+	success = err == nil
+	return err
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refreshState()
+	return cb.state
+}
+
+// before checks whether a call is allowed, and marks the probe as in flight when half-open.
+// It returns whether the call is the probe.
+func (cb *CircuitBreaker) before() (bool, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refreshState()
+	switch cb.state {
+	case CircuitOpen:
+		return false, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.probing {
+			return false, ErrCircuitOpen
+		}
+		cb.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// after records the result of a call.
+// Only the probe moves a half-open circuit, and the results of calls started
+// before the circuit opened are ignored unless it is closed again.
+func (cb *CircuitBreaker) after(probe, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if probe {
+		cb.probing = false
+		if success {
+			cb.state = CircuitClosed
+			cb.failures = 0
+		} else {
+			cb.open()
+		}
+		return
+	}
+	if cb.state != CircuitClosed {
+		return
+	}
+	if success {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.failureThreshold {
+		cb.open()
+	}
+}
+
+// open moves the circuit to the open state.
+func (cb *CircuitBreaker) open() {
+	cb.state = CircuitOpen
+	cb.openedAt = cb.now()
+	cb.failures = 0
+}
+
+// refreshState half-opens the circuit if it has been open for longer than the reset timeout.
+func (cb *CircuitBreaker) refreshState() {
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.resetTimeout {
+		cb.state = CircuitHalfOpen
+		cb.probing = false
+	}
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	cb := NewCircuitBreaker(3, time.Second)
+	cb.now = func() time.Time { return now }
+
+	errDown := errors.New("downstream unavailable")
+	fail := func() error { return errDown }
+	succeed := func() error { return nil }
+
+	// closed -> open after threshold consecutive failures
+	require.ErrorIs(t, cb.Execute(fail), errDown)
+	require.ErrorIs(t, cb.Execute(fail), errDown)
+	require.NoError(t, cb.Execute(succeed))
+	require.Equal(t, CircuitClosed, cb.State())
+	for i := 0; i < 3; i++ {
+		require.ErrorIs(t, cb.Execute(fail), errDown)
+	}
+	require.Equal(t, CircuitOpen, cb.State())
+
+	called := false
+	require.ErrorIs(t, cb.Execute(func() error {
+		called = true
+		return nil
+	}), ErrCircuitOpen)
+	require.False(t, called)
+
+	// open -> half-open after the reset timeout
+	now = now.Add(time.Second)
+	require.Equal(t, CircuitHalfOpen, cb.State())
+
+	// half-open -> open on a failed probe
+	require.ErrorIs(t, cb.Execute(fail), errDown)
+	require.Equal(t, CircuitOpen, cb.State())
+
+	// half-open -> closed on a successful probe
+	now = now.Add(time.Second)
+	require.Equal(t, CircuitHalfOpen, cb.State())
+	require.NoError(t, cb.Execute(succeed))
+	require.Equal(t, CircuitClosed, cb.State())
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	cb := NewCircuitBreaker(1, time.Second)
+	cb.now = func() time.Time { return now }
+
+	require.Error(t, cb.Execute(func() error { return errors.New("boom") }))
+	now = now.Add(time.Second)
+
+	// While the probe is in flight, other calls are rejected.
+	err := cb.Execute(func() error {
+		require.ErrorIs(t, cb.Execute(func() error { return nil }), ErrCircuitOpen)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, CircuitClosed, cb.State())
+	require.Equal(t, "closed", cb.State().String())
+}
+
+func TestCircuitBreakerStaleCallDuringHalfOpen(t *testing.T) {
+	t.Parallel()
+
+	var now atomic.Int64
+	now.Store(time.Unix(1000, 0).UnixNano())
+	cb := NewCircuitBreaker(1, time.Second)
+	cb.now = func() time.Time { return time.Unix(0, now.Load()) }
+
+	// A slow call starts while the circuit is closed
+	startedC := make(chan struct{})
+	releaseC := make(chan struct{})
+	doneC := make(chan error)
+	go func() {
+		doneC <- cb.Execute(func() error {
+			close(startedC)
+			<-releaseC
+			return nil
+		})
+	}()
+	<-startedC
+
+	errDown := errors.New("downstream unavailable")
+	require.ErrorIs(t, cb.Execute(func() error { return errDown }), errDown)
+	require.Equal(t, CircuitOpen, cb.State())
+	now.Add(int64(time.Second))
+
+	// The slow call finishing during the probe does not move the half-open circuit
+	require.ErrorIs(t, cb.Execute(func() error {
+		close(releaseC)
+		require.NoError(t, <-doneC)
+		require.Equal(t, CircuitHalfOpen, cb.State())
+		require.ErrorIs(t, cb.Execute(func() error { return nil }), ErrCircuitOpen)
+		return errDown
+	}), errDown)
+	require.Equal(t, CircuitOpen, cb.State())
+}
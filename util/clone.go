@@ -0,0 +1,100 @@
+package util
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/rambollwong/rainbowcat/types"
+)
+
+// VerifyClone checks a Clone implementation: it clones the original, applies mutate to the clone,
+// and reports whether the original stayed unchanged. A false result usually means Clone is shallow,
+// e.g. it shares a slice, map or pointer between the original and the clone.
+// The original is compared with a reflection-based deep copy taken before cloning, including unexported fields.
+func VerifyClone[T types.Clonable[T]](original T, mutate func(T)) bool {
+	snapshot := deepCopy(original)
+	mutate(original.Clone())
+	return reflect.DeepEqual(original, snapshot)
+}
+
+// deepCopy returns a deep copy of v made by reflection.
+func deepCopy[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	return deepCopyValue(src, make(map[uintptr]reflect.Value)).Interface().(T)
+}
+
+// deepCopyValue returns an addressable deep copy of v.
+// Pointers already copied are looked up in visited, so shared and cyclic pointers are preserved.
+func deepCopyValue(v reflect.Value, visited map[uintptr]reflect.Value) reflect.Value {
+	cp := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return cp
+		}
+		if p, ok := visited[v.Pointer()]; ok {
+			cp.Set(p)
+			return cp
+		}
+		p := reflect.New(v.Type().Elem())
+		visited[v.Pointer()] = p
+		p.Elem().Set(deepCopyValue(addressable(v.Elem()), visited))
+		cp.Set(p)
+	case reflect.Struct:
+		v = addressable(v)
+		for i := 0; i < v.NumField(); i++ {
+			settable(cp.Field(i)).Set(deepCopyValue(settable(v.Field(i)), visited))
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return cp
+		}
+		cp.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Cap()))
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopyValue(v.Index(i), visited))
+		}
+	case reflect.Array:
+		v = addressable(v)
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopyValue(v.Index(i), visited))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return cp
+		}
+		cp.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(
+				deepCopyValue(addressable(iter.Key()), visited),
+				deepCopyValue(addressable(iter.Value()), visited),
+			)
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return cp
+		}
+		cp.Set(deepCopyValue(addressable(v.Elem()), visited))
+	default:
+		cp.Set(v)
+	}
+	return cp
+}
+
+// addressable returns v itself if it is addressable, otherwise an addressable copy of it.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+	return cp
+}
+
+// settable returns an alias of the addressable value v which can be read and set even if v is an unexported field.
+func settable(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type deepClonable struct {
+	Name   string
+	Tags   []string
+	labels map[string]string
+}
+
+func (c deepClonable) Clone() deepClonable {
+	cp := c
+	cp.Tags = append([]string(nil), c.Tags...)
+	cp.labels = make(map[string]string, len(c.labels))
+	for k, v := range c.labels {
+		cp.labels[k] = v
+	}
+	return cp
+}
+
+type shallowClonable struct {
+	Name   string
+	Tags   []string
+	labels map[string]string
+}
+
+func (c shallowClonable) Clone() shallowClonable {
+	return c
+}
+
+func TestVerifyClone(t *testing.T) {
+	t.Parallel()
+
+	deep := deepClonable{Name: "a", Tags: []string{"x", "y"}, labels: map[string]string{"k": "v"}}
+	require.True(t, VerifyClone(deep, func(c deepClonable) {
+		c.Tags[0] = "changed"
+		c.labels["k"] = "changed"
+	}))
+
+	shallow := shallowClonable{Name: "a", Tags: []string{"x", "y"}, labels: map[string]string{"k": "v"}}
+	require.False(t, VerifyClone(shallow, func(c shallowClonable) {
+		c.Tags[0] = "changed"
+	}))
+	// The unexported map is shared too
+	require.False(t, VerifyClone(shallow, func(c shallowClonable) {
+		c.labels["k2"] = "v2"
+	}))
+	// Mutating only the copied fields is fine even for a shallow clone
+	require.True(t, VerifyClone(shallow, func(c shallowClonable) {
+		c.Name = "changed"
+	}))
+}
+
+func TestDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Value int
+		Next  *node
+		any   any
+		arr   [2][]int
+	}
+	n1 := &node{Value: 1, any: []int{1}, arr: [2][]int{{1}, {2}}}
+	n2 := &node{Value: 2, Next: n1}
+	n1.Next = n2
+
+	cp := deepCopy(n1)
+	require.NotSame(t, n1, cp)
+	require.Equal(t, 1, cp.Value)
+	require.Equal(t, 2, cp.Next.Value)
+	// The cycle is preserved within the copy
+	require.Same(t, cp, cp.Next.Next)
+	require.Equal(t, []int{1}, cp.any)
+	cp.any.([]int)[0] = 10
+	cp.arr[0][0] = 10
+	require.Equal(t, []int{1}, n1.any)
+	require.Equal(t, 1, n1.arr[0][0])
+}
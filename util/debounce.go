@@ -0,0 +1,47 @@
+package util
+
+import "time"
+
+// DebounceChannel coalesces bursts of values from the in channel.
+// The returned channel emits the latest value received only after in has been quiet for d.
+// When in is closed, any pending value is emitted and the returned channel is closed.
+func DebounceChannel[T any](in <-chan T, d time.Duration) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		var (
+			latest  T
+			pending bool
+			timer   = time.NewTimer(d)
+		)
+		if !timer.Stop() {
+			<-timer.C
+		}
+		defer timer.Stop()
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if pending {
+						out <- latest
+					}
+					return
+				}
+				latest, pending = v, true
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(d)
+			case <-timer.C:
+				if pending {
+					out <- latest
+					pending = false
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebounceChannel(t *testing.T) {
+	t.Parallel()
+
+	in := make(chan int)
+	out := DebounceChannel(in, 50*time.Millisecond)
+
+	start := time.Now()
+	for i := 1; i <= 5; i++ {
+		in <- i
+		time.Sleep(5 * time.Millisecond)
+	}
+	select {
+	case v := <-out:
+		require.Equal(t, 5, v)
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	case <-time.After(time.Second):
+		t.Fatal("debounced value was not emitted")
+	}
+
+	// A pending value is flushed when the input closes
+	in <- 6
+	in <- 7
+	close(in)
+	v, ok := <-out
+	require.True(t, ok)
+	require.Equal(t, 7, v)
+	_, ok = <-out
+	require.False(t, ok)
+}
@@ -0,0 +1,71 @@
+package util
+
+import "reflect"
+
+// DeepEqualIgnoring reports whether a and b are deeply equal like reflect.DeepEqual,
+// but skips the named fields when a and b are structs or pointers to structs.
+// Field names are matched against the top-level struct, including fields promoted from embedded structs,
+// and may name unexported fields. Unknown field names are ignored.
+func DeepEqualIgnoring(a, b any, ignoreFields ...string) bool {
+	if len(ignoreFields) == 0 {
+		return reflect.DeepEqual(a, b)
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return reflect.DeepEqual(a, b)
+	}
+	ca, okA := copyStructIgnoring(va, ignoreFields)
+	cb, okB := copyStructIgnoring(vb, ignoreFields)
+	if !okA || !okB {
+		return reflect.DeepEqual(a, b)
+	}
+	return reflect.DeepEqual(ca.Interface(), cb.Interface())
+}
+
+// copyStructIgnoring returns a copy of the struct (or the struct pointed to) with the named fields zeroed.
+// It returns false if v is neither a struct nor a non-nil pointer to a struct.
+func copyStructIgnoring(v reflect.Value, ignoreFields []string) (reflect.Value, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return v, false
+	}
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+	for _, name := range ignoreFields {
+		sf, ok := cp.Type().FieldByName(name)
+		if !ok {
+			continue
+		}
+		f, ok := fieldByIndexCopying(cp, sf.Index)
+		if !ok {
+			continue
+		}
+		f.Set(reflect.Zero(f.Type()))
+	}
+	return cp, true
+}
+
+// fieldByIndexCopying returns the settable nested field of the struct v by index, like reflect.Value.FieldByIndex.
+// Each embedded pointer on the path is replaced by a pointer to a copy of its target,
+// so that setting the field never modifies the original data shared through the pointer.
+// It returns false if an embedded pointer on the path is nil, i.e. the field is absent.
+func fieldByIndexCopying(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return v, false
+			}
+			cp := reflect.New(v.Type().Elem())
+			cp.Elem().Set(v.Elem())
+			v.Set(cp)
+			v = cp.Elem()
+		}
+		v = settable(v.Field(x))
+	}
+	return v, true
+}
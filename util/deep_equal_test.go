@@ -0,0 +1,73 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepEqualIgnoring(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		ID        int
+		Name      string
+		Tags      []string
+		CreatedAt time.Time
+		version   int
+	}
+
+	a := record{ID: 1, Name: "a", Tags: []string{"x"}, CreatedAt: time.Now(), version: 1}
+	b := record{ID: 2, Name: "a", Tags: []string{"x"}, CreatedAt: time.Now().Add(time.Hour), version: 2}
+
+	require.False(t, DeepEqualIgnoring(a, b))
+	require.False(t, DeepEqualIgnoring(a, b, "ID", "CreatedAt"))
+	require.True(t, DeepEqualIgnoring(a, b, "ID", "CreatedAt", "version"))
+	require.True(t, DeepEqualIgnoring(&a, &b, "ID", "CreatedAt", "version", "Unknown"))
+
+	b.Tags = []string{"y"}
+	require.False(t, DeepEqualIgnoring(a, b, "ID", "CreatedAt", "version"))
+
+	// The inputs are not modified
+	require.Equal(t, 1, a.ID)
+	require.Equal(t, 1, a.version)
+
+	require.True(t, DeepEqualIgnoring(1, 1, "ID"))
+	require.False(t, DeepEqualIgnoring(1, "1", "ID"))
+	require.False(t, DeepEqualIgnoring(&a, nil, "ID"))
+}
+
+func TestDeepEqualIgnoringEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		ID   int
+		Kind string
+	}
+	type outer struct {
+		*inner
+		Name string
+	}
+
+	a := outer{inner: &inner{ID: 1, Kind: "x"}, Name: "a"}
+	b := outer{inner: &inner{ID: 2, Kind: "x"}, Name: "a"}
+
+	require.False(t, DeepEqualIgnoring(a, b, "Name"))
+	require.True(t, DeepEqualIgnoring(a, b, "ID"))
+	require.True(t, DeepEqualIgnoring(&a, &b, "ID", "Name"))
+
+	// The data shared through the embedded pointers is not modified
+	require.Equal(t, 1, a.ID)
+	require.Equal(t, 2, b.ID)
+
+	b.Kind = "y"
+	require.False(t, DeepEqualIgnoring(a, b, "ID"))
+	require.True(t, DeepEqualIgnoring(a, b, "ID", "Kind"))
+
+	// A field promoted through a nil embedded pointer is absent
+	c := outer{Name: "a"}
+	d := outer{Name: "a"}
+	require.True(t, DeepEqualIgnoring(c, d, "ID"))
+	require.False(t, DeepEqualIgnoring(a, c, "ID"))
+}
@@ -0,0 +1,119 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvTag is the struct tag naming the environment variable of a field for LoadEnvConfig.
+	EnvTag = "env"
+	// EnvBytesOption is the option of EnvTag to parse the variable as a byte size, e.g., `env:"MAX_SIZE,bytes"`.
+	EnvBytesOption = "bytes"
+)
+
+// LoadEnvConfig populates the fields of the struct pointed to by v from the environment variables named by their `env` tags.
+// Fields without the tag, or whose variable is not set, are left unchanged, so they may hold default values.
+// Strings are set as is, bools are parsed by ParseBool, time.Duration fields by time.ParseDuration,
+// and other ints, uints and floats by strconv. With the bytes option, e.g., `env:"MAX_SIZE,bytes"`,
+// an int or uint field is parsed by ParseToBytesSizeStrict with base 1024.
+func LoadEnvConfig(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("config must be a non-nil pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup(EnvTag)
+		if !ok {
+			continue
+		}
+		name, option, _ := strings.Cut(tag, ",")
+		if name == "" {
+			return fmt.Errorf("field %s: empty env name", field.Name)
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("field %s: unexported field", field.Name)
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setEnvField(rv.Field(i), value, option == EnvBytesOption); err != nil {
+			return fmt.Errorf("env %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setEnvField parses the value according to the type of the field and sets it.
+func setEnvField(field reflect.Value, value string, bytesSize bool) error {
+	if bytesSize {
+		size, err := ParseToBytesSizeStrict(value, 1024)
+		if err != nil {
+			return err
+		}
+		switch field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if field.OverflowInt(size) {
+				return errors.New("bytes size overflows field")
+			}
+			field.SetInt(size)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if field.OverflowUint(uint64(size)) {
+				return errors.New("bytes size overflows field")
+			}
+			field.SetUint(uint64(size))
+		default:
+			return fmt.Errorf("unsupported bytes size field type %s", field.Type())
+		}
+		return nil
+	}
+
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEnvConfig(t *testing.T) {
+	type config struct {
+		Name     string        `env:"RC_TEST_NAME"`
+		Workers  int           `env:"RC_TEST_WORKERS"`
+		Debug    bool          `env:"RC_TEST_DEBUG"`
+		Timeout  time.Duration `env:"RC_TEST_TIMEOUT"`
+		MaxSize  int64         `env:"RC_TEST_MAX_SIZE,bytes"`
+		Ratio    float64       `env:"RC_TEST_RATIO"`
+		Port     uint16        `env:"RC_TEST_PORT"`
+		Region   string        `env:"RC_TEST_UNSET"`
+		Untagged string
+	}
+
+	t.Setenv("RC_TEST_NAME", "rainbowcat")
+	t.Setenv("RC_TEST_WORKERS", "8")
+	t.Setenv("RC_TEST_DEBUG", "yes")
+	t.Setenv("RC_TEST_TIMEOUT", "1m30s")
+	t.Setenv("RC_TEST_MAX_SIZE", "2M")
+	t.Setenv("RC_TEST_RATIO", "0.75")
+	t.Setenv("RC_TEST_PORT", "8080")
+
+	cfg := config{Region: "default", Untagged: "kept"}
+	require.NoError(t, LoadEnvConfig(&cfg))
+	require.Equal(t, config{
+		Name:     "rainbowcat",
+		Workers:  8,
+		Debug:    true,
+		Timeout:  90 * time.Second,
+		MaxSize:  2 * 1024 * 1024,
+		Ratio:    0.75,
+		Port:     8080,
+		Region:   "default",
+		Untagged: "kept",
+	}, cfg)
+
+	t.Setenv("RC_TEST_WORKERS", "eight")
+	require.ErrorContains(t, LoadEnvConfig(&cfg), "RC_TEST_WORKERS")
+	t.Setenv("RC_TEST_WORKERS", "8")
+	t.Setenv("RC_TEST_PORT", "65536")
+	require.Error(t, LoadEnvConfig(&cfg))
+
+	require.Error(t, LoadEnvConfig(cfg))
+	require.Error(t, LoadEnvConfig((*config)(nil)))
+	require.Error(t, LoadEnvConfig(nil))
+}
@@ -3,9 +3,23 @@ package util
 import (
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rambollwong/rainbowcat/pool"
 )
 
+// gzipWriterPool caches gzip writers with gzip.BestSpeed level for GZipCompressBytesPooled.
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		g, _ := gzip.NewWriterLevel(io.Discard, gzip.BestSpeed)
+		return g
+	},
+}
+
 // GZipCompressBytes compresses a byte slice using gzip compression.
 // It returns the compressed byte slice and any error encountered during the compression process.
 func GZipCompressBytes(data []byte) ([]byte, error) {
@@ -25,6 +39,36 @@ func GZipCompressBytes(data []byte) ([]byte, error) {
 	return input.Bytes(), nil
 }
 
+// GZipCompressBytesPooled compresses a byte slice using gzip compression like GZipCompressBytes,
+// but writes the output to a bytes slice borrowed from the global bytes pool.
+// The returned slice is owned by the caller until it is given back with GZipReleasePooled.
+// After the release, the returned slice and any slice sharing its backing array must not be used.
+// If the result needs to outlive the release, copy it first.
+func GZipCompressBytesPooled(data []byte) ([]byte, error) {
+	buf := NewPooledBuffer(nil)
+	g := gzipWriterPool.Get().(*gzip.Writer)
+	defer gzipWriterPool.Put(g)
+	g.Reset(buf)
+	if _, err := g.Write(data); err != nil {
+		buf.Release()
+		return nil, err
+	}
+	if err := g.Close(); err != nil {
+		buf.Release()
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GZipReleasePooled gives a slice returned by GZipCompressBytesPooled back to the global bytes pool.
+// Releasing a slice not returned by GZipCompressBytesPooled, or releasing the same slice twice, is not allowed.
+func GZipReleasePooled(bz []byte) {
+	if bz == nil {
+		return
+	}
+	pool.BytesPoolPut(&bz)
+}
+
 // GZipDecompressBytes decompresses a byte slice using gzip decompression.
 // It returns the decompressed byte slice and any error encountered during the decompression process.
 func GZipDecompressBytes(data []byte) ([]byte, error) {
@@ -46,3 +90,82 @@ func GZipDecompressBytes(data []byte) ([]byte, error) {
 	}
 	return out.Bytes(), nil
 }
+
+// GZipCompressFile streams the file src through gzip compression into the file dst, e.g., to compress rolled log files.
+// If removeSrc is true, src is removed after dst has been written successfully.
+// If an error occurs, the partially written dst is removed and src is kept.
+// It returns an error if src and dst are the same path.
+func GZipCompressFile(src, dst string, removeSrc bool) error {
+	return transformFile(src, dst, removeSrc, func(w io.Writer, r io.Reader) error {
+		g, err := gzip.NewWriterLevel(w, gzip.BestSpeed)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(g, r); err != nil {
+			return err
+		}
+		return g.Close()
+	})
+}
+
+// GZipDecompressFile streams the gzip compressed file src through gzip decompression into the file dst.
+// If removeSrc is true, src is removed after dst has been written successfully.
+// If an error occurs, the partially written dst is removed and src is kept.
+// It returns an error if src and dst are the same path.
+func GZipDecompressFile(src, dst string, removeSrc bool) error {
+	return transformFile(src, dst, removeSrc, func(w io.Writer, r io.Reader) error {
+		g, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		// nolint:gosec
+		if _, err = io.Copy(w, g); err != nil {
+			return err
+		}
+		return g.Close()
+	})
+}
+
+// transformFile writes the content of the file src transformed by transform into the file dst.
+func transformFile(src, dst string, removeSrc bool, transform func(w io.Writer, r io.Reader) error) (err error) {
+	// Creating dst would truncate src before it is read
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if absSrc == absDst {
+		return errors.New("src and dst are the same file")
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = out.Close()
+			_ = os.Remove(dst)
+		}
+	}()
+	if err = transform(out, in); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+	if removeSrc {
+		_ = in.Close()
+		return os.Remove(src)
+	}
+	return nil
+}
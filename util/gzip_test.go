@@ -0,0 +1,69 @@
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGZipCompressBytesPooled(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range [][]byte{
+		nil,
+		[]byte("hello rainbowcat"),
+		bytes.Repeat([]byte("abcdefgh"), 4096),
+	} {
+		expected, err := GZipCompressBytes(data)
+		require.NoError(t, err)
+
+		compressed, err := GZipCompressBytesPooled(data)
+		require.NoError(t, err)
+		require.Equal(t, expected, compressed)
+
+		decompressed, err := GZipDecompressBytes(compressed)
+		require.NoError(t, err)
+		require.Equal(t, len(data), len(decompressed))
+		GZipReleasePooled(compressed)
+	}
+}
+
+func TestGZipCompressAndDecompressFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.log")
+	compressed := filepath.Join(dir, "app.log.gz")
+	decompressed := filepath.Join(dir, "app.restored.log")
+	data := bytes.Repeat([]byte("2024-01-01 INFO hello rainbowcat\n"), 1024)
+	require.NoError(t, os.WriteFile(src, data, 0644))
+
+	require.NoError(t, GZipCompressFile(src, compressed, false))
+	_, err := os.Stat(src)
+	require.NoError(t, err)
+	require.NoError(t, GZipDecompressFile(compressed, decompressed, true))
+	_, err = os.Stat(compressed)
+	require.True(t, os.IsNotExist(err))
+
+	restored, err := os.ReadFile(decompressed)
+	require.NoError(t, err)
+	require.Equal(t, data, restored)
+
+	// Decompressing a file that is not gzip compressed fails, and no dst is left behind
+	invalid := filepath.Join(dir, "invalid.log")
+	require.Error(t, GZipDecompressFile(src, invalid, true))
+	_, err = os.Stat(invalid)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(src)
+	require.NoError(t, err)
+
+	// The same src and dst are rejected without touching the file
+	require.Error(t, GZipCompressFile(src, src, false))
+	require.Error(t, GZipDecompressFile(compressed, filepath.Join(dir, ".", "app.log.gz"), false))
+	content, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.Equal(t, data, content)
+}
@@ -0,0 +1,45 @@
+package util
+
+import "sync"
+
+// keyedMutexEntry is a mutex of a key with the number of callers holding or waiting for it.
+type keyedMutexEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// KeyedMutex provides a mutex per key, e.g., for serializing writes per user ID.
+// The mutex of a key is removed once no caller holds or waits for it, so unused keys do not leak memory.
+// The zero value is ready to use.
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedMutexEntry
+}
+
+// Lock locks the mutex of the key, blocking until it is available, and returns the function to unlock it.
+// The unlock function must be called exactly once.
+func (km *KeyedMutex[K]) Lock(k K) (unlock func()) {
+	km.mu.Lock()
+	if km.locks == nil {
+		km.locks = make(map[K]*keyedMutexEntry)
+	}
+	e, ok := km.locks[k]
+	if !ok {
+		e = &keyedMutexEntry{}
+		km.locks[k] = e
+	}
+	e.refs++
+	km.mu.Unlock()
+
+	e.mu.Lock()
+	return func() {
+		e.mu.Unlock()
+
+		km.mu.Lock()
+		defer km.mu.Unlock()
+		e.refs--
+		if e.refs == 0 {
+			delete(km.locks, k)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	t.Parallel()
+
+	var (
+		km      KeyedMutex[int]
+		wg      sync.WaitGroup
+		running atomic.Int32
+		maxRun  atomic.Int32
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := km.Lock(1)
+			defer unlock()
+			n := running.Add(1)
+			if n > maxRun.Load() {
+				maxRun.Store(n)
+			}
+			time.Sleep(50 * time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+
+	// A different key proceeds while key 1 is held
+	time.Sleep(10 * time.Millisecond)
+	lockedC := make(chan struct{})
+	go func() {
+		unlock := km.Lock(2)
+		unlock()
+		close(lockedC)
+	}()
+	select {
+	case <-lockedC:
+	case <-time.After(30 * time.Millisecond):
+		t.Fatal("locking a different key was blocked")
+	}
+
+	wg.Wait()
+	require.Equal(t, int32(1), maxRun.Load())
+
+	// Unused locks are cleaned up
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	require.Empty(t, km.locks)
+}
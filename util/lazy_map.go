@@ -0,0 +1,76 @@
+package util
+
+import "sync"
+
+// LazyMap is a thread-safe map whose values are computed on the first access of each key.
+// Concurrent first accesses of a key share a single computation. Deleted keys are computed again on the next access.
+type LazyMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*lazyEntry[V]
+	compute func(K) V
+}
+
+// lazyEntry is a value of LazyMap, computed once.
+type lazyEntry[V any] struct {
+	once  sync.Once
+	v     V
+	panic any
+}
+
+// NewLazyMap creates a new LazyMap instance computing values by compute.
+func NewLazyMap[K comparable, V any](compute func(K) V) *LazyMap[K, V] {
+	return &LazyMap[K, V]{
+		entries: make(map[K]*lazyEntry[V]),
+		compute: compute,
+	}
+}
+
+// Get returns the value of the key, computing and storing it first if the key is accessed for the first time.
+// If compute panics, the key is left uncomputed and the panic propagates to all callers waiting for the computation.
+func (m *LazyMap[K, V]) Get(k K) V {
+	m.mu.Lock()
+	e, ok := m.entries[k]
+	if !ok {
+		e = &lazyEntry[V]{}
+		m.entries[k] = e
+	}
+	m.mu.Unlock()
+
+	e.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				e.panic = r
+				m.remove(k, e)
+			}
+		}()
+		e.v = m.compute(k)
+	})
+	if e.panic != nil {
+		panic(e.panic)
+	}
+	return e.v
+}
+
+// Delete removes the value of the key, so that the next Get computes it again.
+// A computation in progress is not interrupted, but its result is not stored for later Gets.
+func (m *LazyMap[K, V]) Delete(k K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, k)
+}
+
+// Len returns the number of keys computed or being computed.
+func (m *LazyMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// remove removes the entry of the key if it has not been replaced.
+func (m *LazyMap[K, V]) remove(k K, e *lazyEntry[V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries[k] == e {
+		delete(m.entries, k)
+	}
+}
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyMap(t *testing.T) {
+	t.Parallel()
+
+	var calls int64
+	m := NewLazyMap(func(k int) int {
+		atomic.AddInt64(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return k * 2
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, 42, m.Get(21))
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int64(1), atomic.LoadInt64(&calls))
+	require.Equal(t, 1, m.Len())
+
+	require.Equal(t, 2, m.Get(1))
+	require.Equal(t, 2, m.Len())
+	require.Equal(t, int64(2), atomic.LoadInt64(&calls))
+
+	// Delete forces recomputation
+	m.Delete(21)
+	require.Equal(t, 1, m.Len())
+	require.Equal(t, 42, m.Get(21))
+	require.Equal(t, int64(3), atomic.LoadInt64(&calls))
+}
+
+func TestLazyMapComputePanics(t *testing.T) {
+	t.Parallel()
+
+	fail := true
+	m := NewLazyMap(func(k string) string {
+		if fail {
+			panic("compute failed")
+		}
+		return k
+	})
+
+	require.Panics(t, func() {
+		m.Get("a")
+	})
+	require.Equal(t, 0, m.Len())
+
+	fail = false
+	require.Equal(t, "a", m.Get("a"))
+}
+
+func TestLazyMapComputePanicsForWaiters(t *testing.T) {
+	t.Parallel()
+
+	startedC := make(chan struct{})
+	releaseC := make(chan struct{})
+	m := NewLazyMap(func(k string) string {
+		close(startedC)
+		<-releaseC
+		panic("compute failed")
+	})
+
+	var (
+		wg     sync.WaitGroup
+		panics atomic.Int64
+	)
+	get := func() {
+		defer wg.Done()
+		defer func() {
+			if recover() != nil {
+				panics.Add(1)
+			}
+		}()
+		m.Get("a")
+	}
+	wg.Add(1)
+	go get()
+	<-startedC
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go get()
+	}
+	// Give the waiters a chance to block on the computation
+	time.Sleep(20 * time.Millisecond)
+	close(releaseC)
+	wg.Wait()
+
+	require.Equal(t, int64(6), panics.Load())
+	require.Equal(t, 0, m.Len())
+}
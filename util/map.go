@@ -1,6 +1,11 @@
 package util
 
-import "github.com/rambollwong/rainbowcat/types"
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rambollwong/rainbowcat/types"
+)
 
 // MapKeys creates an array of the map keys.
 func MapKeys[K comparable, V any](in map[K]V) []K {
@@ -20,6 +25,46 @@ func MapValues[K comparable, V any](in map[K]V) []V {
 	return result
 }
 
+// MapKeysTransform creates an array of the map keys transformed by the given function.
+func MapKeysTransform[K comparable, V any, R any](in map[K]V, fn func(K) R) []R {
+	result := make([]R, 0, len(in))
+	for k := range in {
+		result = append(result, fn(k))
+	}
+	return result
+}
+
+// MapValuesTransform creates an array of the map values transformed by the given function.
+func MapValuesTransform[K comparable, V any, R any](in map[K]V, fn func(V) R) []R {
+	result := make([]R, 0, len(in))
+	for _, v := range in {
+		result = append(result, fn(v))
+	}
+	return result
+}
+
+// MapKeysMatching creates an array of the keys of the map entries predicate returns truthy for.
+func MapKeysMatching[K comparable, V any](in map[K]V, predicate func(k K, v V) bool) []K {
+	result := make([]K, 0)
+	for k, v := range in {
+		if predicate(k, v) {
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// MapValuesMatching creates an array of the values of the map entries predicate returns truthy for.
+func MapValuesMatching[K comparable, V any](in map[K]V, predicate func(k K, v V) bool) []V {
+	result := make([]V, 0)
+	for k, v := range in {
+		if predicate(k, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 // MapValueOr returns the value of the given key or the fallback value if the key is not present.
 func MapValueOr[K comparable, V any](in map[K]V, key K, fallback V) V {
 	if v, ok := in[key]; ok {
@@ -39,6 +84,20 @@ func MapFilter[K comparable, V any](in map[K]V, predicate func(key K, value V) b
 	return r
 }
 
+// MapPartition splits a map into the entries predicate returns truthy for and the other entries.
+// Both returned maps are non-nil.
+func MapPartition[K comparable, V any](in map[K]V, predicate func(k K, v V) bool) (matched, unmatched map[K]V) {
+	matched, unmatched = map[K]V{}, map[K]V{}
+	for k, v := range in {
+		if predicate(k, v) {
+			matched[k] = v
+		} else {
+			unmatched[k] = v
+		}
+	}
+	return matched, unmatched
+}
+
 // MapFilterByKeys returns same map type filtered by given keys.
 func MapFilterByKeys[K comparable, V any](in map[K]V, keys []K) map[K]V {
 	r := map[K]V{}
@@ -138,6 +197,37 @@ func MapAssign[K comparable, V any](maps ...map[K]V) map[K]V {
 	return out
 }
 
+// MapMergeInto merges multiple maps into dst from left to right, without allocating a new map.
+// dst is modified in place, and its existing keys are overwritten by the values of later maps. dst must not be nil.
+func MapMergeInto[K comparable, V any](dst map[K]V, srcs ...map[K]V) {
+	for _, m := range srcs {
+		for k, v := range m {
+			dst[k] = v
+		}
+	}
+}
+
+// MapDeepMerge merges src into dst recursively and returns the result as a new map, e.g., for layered configs.
+// Nested map[string]any values present in both maps are merged rather than overwritten wholesale,
+// and any other value from src wins. Neither dst nor src is modified, and nested maps are copied.
+func MapDeepMerge(dst, src map[string]any) map[string]any {
+	out := make(map[string]any, len(dst)+len(src))
+	for k, v := range dst {
+		if m, ok := v.(map[string]any); ok {
+			v = MapDeepMerge(m, nil)
+		}
+		out[k] = v
+	}
+	for k, v := range src {
+		if m, ok := v.(map[string]any); ok {
+			existing, _ := out[k].(map[string]any)
+			v = MapDeepMerge(existing, m)
+		}
+		out[k] = v
+	}
+	return out
+}
+
 // MapTransformKeys manipulates a map keys and transforms it to a map of another type.
 func MapTransformKeys[K comparable, V any, R comparable](in map[K]V, iteratee func(value V, key K) R) map[R]V {
 	result := make(map[R]V, len(in))
@@ -169,6 +259,24 @@ func MapTransformKeyValues[K1 comparable, V1 any, K2 comparable, V2 any](
 	return result
 }
 
+// MapFlatten flattens a two-level map into a single map whose keys are the outer and inner keys joined by join.
+// If join produces the same key for several entries, the last one visited wins.
+// As the iteration order of a map is unspecified, the surviving value is unspecified too,
+// so join should produce distinct keys for distinct key pairs.
+func MapFlatten[K1 comparable, K2 comparable, V any](in map[K1]map[K2]V, join func(k1 K1, k2 K2) string) map[string]V {
+	size := 0
+	for _, inner := range in {
+		size += len(inner)
+	}
+	result := make(map[string]V, size)
+	for k1, inner := range in {
+		for k2, v := range inner {
+			result[join(k1, k2)] = v
+		}
+	}
+	return result
+}
+
 // MapToSlice transforms a map into a slice based on specific iteratee.
 func MapToSlice[K comparable, V any, R any](in map[K]V, iteratee func(key K, value V) R) []R {
 	result := make([]R, 0, len(in))
@@ -179,3 +287,49 @@ func MapToSlice[K comparable, V any, R any](in map[K]V, iteratee func(key K, val
 
 	return result
 }
+
+// MapReduce reduces a map to a value which is the accumulated result of running each entry through reduce,
+// where each successive invocation is supplied the return value of the previous.
+// The iteration order of a map is unspecified, so reduce should not depend on the order of the entries.
+func MapReduce[K comparable, V any, R any](in map[K]V, reduce func(agg R, k K, v V) R, initial R) R {
+	for k, v := range in {
+		initial = reduce(initial, k, v)
+	}
+	return initial
+}
+
+// MapDiff compares two maps and returns the entries only in new (added), the entries only in old (removed),
+// and the keys present in both with differing values, mapped to their [old, new] value pair (changed).
+func MapDiff[K comparable, V comparable](old, new map[K]V) (added, removed map[K]V, changed map[K][2]V) {
+	added = map[K]V{}
+	removed = map[K]V{}
+	changed = map[K][2]V{}
+	for k, oldV := range old {
+		newV, ok := new[k]
+		if !ok {
+			removed[k] = oldV
+			continue
+		}
+		if oldV != newV {
+			changed[k] = [2]V{oldV, newV}
+		}
+	}
+	for k, newV := range new {
+		if _, ok := old[k]; !ok {
+			added[k] = newV
+		}
+	}
+	return added, removed, changed
+}
+
+// MapToSortedSlice transforms a map into a slice based on specific iteratee,
+// iterating the keys in ascending order so that the output is deterministic.
+func MapToSortedSlice[K cmp.Ordered, V any, R any](in map[K]V, iteratee func(key K, value V) R) []R {
+	keys := MapKeys(in)
+	slices.Sort(keys)
+	result := make([]R, 0, len(in))
+	for _, k := range keys {
+		result = append(result, iteratee(k, in[k]))
+	}
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/rambollwong/rainbowcat/types"
@@ -28,6 +29,54 @@ func TestMapValues(t *testing.T) {
 	require.Equal(t, []int{1, 2}, res1)
 }
 
+func TestMapKeysTransform(t *testing.T) {
+	t.Parallel()
+
+	res1 := MapKeysTransform(map[int]string{1: "a", 2: "b", 3: "c"}, strconv.Itoa)
+	sort.Strings(res1)
+
+	require.Equal(t, []string{"1", "2", "3"}, res1)
+	require.Empty(t, MapKeysTransform(map[int]string{}, strconv.Itoa))
+}
+
+func TestMapValuesTransform(t *testing.T) {
+	t.Parallel()
+
+	res1 := MapValuesTransform(map[string]int{"a": 1, "b": 2}, func(v int) string {
+		return strconv.Itoa(v * 10)
+	})
+	sort.Strings(res1)
+
+	require.Equal(t, []string{"10", "20"}, res1)
+}
+
+func TestMapKeysMatching(t *testing.T) {
+	t.Parallel()
+
+	in := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	res1 := MapKeysMatching(in, func(k string, v int) bool {
+		return v > 2
+	})
+	sort.Strings(res1)
+	res2 := MapKeysMatching(in, func(k string, v int) bool {
+		return false
+	})
+
+	require.Equal(t, []string{"c", "d"}, res1)
+	require.Equal(t, []string{}, res2)
+}
+
+func TestMapValuesMatching(t *testing.T) {
+	t.Parallel()
+
+	res1 := MapValuesMatching(map[string]int{"a": 1, "b": 2, "c": 3}, func(k string, v int) bool {
+		return k != "b"
+	})
+	sort.Ints(res1)
+
+	require.Equal(t, []int{1, 3}, res1)
+}
+
 func TestMapValueOr(t *testing.T) {
 	t.Parallel()
 
@@ -48,6 +97,23 @@ func TestMapFilter(t *testing.T) {
 	require.Equal(t, map[string]int{"b": 2}, res1)
 }
 
+func TestMapPartition(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(k string, v int) bool {
+		return v%2 == 0
+	}
+	matched, unmatched := MapPartition(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}, isEven)
+	require.Equal(t, map[string]int{"b": 2, "d": 4}, matched)
+	require.Equal(t, map[string]int{"a": 1, "c": 3}, unmatched)
+
+	matched, unmatched = MapPartition(nil, isEven)
+	require.NotNil(t, matched)
+	require.NotNil(t, unmatched)
+	require.Empty(t, matched)
+	require.Empty(t, unmatched)
+}
+
 func TestMapFilterByKeys(t *testing.T) {
 	t.Parallel()
 
@@ -155,6 +221,53 @@ func TestMapInvert(t *testing.T) {
 	require.Equal(t, map[int]string{1: "a", 2: "b", 3: "c"}, res1)
 }
 
+func TestMapMergeInto(t *testing.T) {
+	t.Parallel()
+
+	dst := map[string]int{"a": 1, "b": 2}
+	MapMergeInto(dst, map[string]int{"b": 3, "c": 4}, nil, map[string]int{"c": 5, "d": 6})
+
+	require.Equal(t, map[string]int{"a": 1, "b": 3, "c": 5, "d": 6}, dst)
+}
+
+func TestMapDeepMerge(t *testing.T) {
+	t.Parallel()
+
+	defaults := map[string]any{
+		"name": "app",
+		"log": map[string]any{
+			"level": "info",
+			"file":  "app.log",
+		},
+		"port": 8080,
+	}
+	overrides := map[string]any{
+		"log": map[string]any{
+			"level": "debug",
+		},
+		"port":  map[string]any{"http": 80},
+		"debug": true,
+	}
+
+	res := MapDeepMerge(defaults, overrides)
+	require.Equal(t, map[string]any{
+		"name": "app",
+		"log": map[string]any{
+			"level": "debug",
+			"file":  "app.log",
+		},
+		"port":  map[string]any{"http": 80},
+		"debug": true,
+	}, res)
+
+	// Neither input is modified
+	require.Equal(t, "info", defaults["log"].(map[string]any)["level"])
+	res["log"].(map[string]any)["file"] = "other.log"
+	require.Equal(t, "app.log", defaults["log"].(map[string]any)["file"])
+
+	require.Equal(t, map[string]any{}, MapDeepMerge(nil, nil))
+}
+
 func TestMapAssign(t *testing.T) {
 	t.Parallel()
 
@@ -191,6 +304,28 @@ func TestMapTransformKeyValues(t *testing.T) {
 	require.Equal(t, map[float64]string{1.0: "1", 2.0: "2"}, res1)
 }
 
+func TestMapFlatten(t *testing.T) {
+	t.Parallel()
+
+	join := func(k1 string, k2 string) string {
+		return strings.ToUpper(k1 + "_" + k2)
+	}
+	res1 := MapFlatten(map[string]map[string]int{
+		"db":    {"port": 5432, "pool": 10},
+		"cache": {"port": 6379},
+		"empty": {},
+	}, join)
+	require.Equal(t, map[string]int{"DB_PORT": 5432, "DB_POOL": 10, "CACHE_PORT": 6379}, res1)
+
+	// Colliding composite keys keep a single entry.
+	res2 := MapFlatten(map[string]map[string]int{
+		"a":   {"b_c": 1},
+		"a_b": {"c": 2},
+	}, join)
+	require.Len(t, res2, 1)
+	require.Contains(t, []int{1, 2}, res2["A_B_C"])
+}
+
 func TestMapToSlice(t *testing.T) {
 	t.Parallel()
 
@@ -199,3 +334,53 @@ func TestMapToSlice(t *testing.T) {
 	})
 	require.Equal(t, []string{"1-2", "2-3"}, res1)
 }
+
+func TestMapReduce(t *testing.T) {
+	t.Parallel()
+
+	in := map[string]int{"a": 1, "b": 2, "c": 3}
+	sum := MapReduce(in, func(agg int, k string, v int) int {
+		return agg + v
+	}, 0)
+	count := MapReduce(in, func(agg int, k string, v int) int {
+		return agg + 1
+	}, 0)
+	empty := MapReduce(map[string]int{}, func(agg int, k string, v int) int {
+		return agg + v
+	}, 10)
+
+	require.Equal(t, 6, sum)
+	require.Equal(t, 3, count)
+	require.Equal(t, 10, empty)
+}
+
+func TestMapDiff(t *testing.T) {
+	t.Parallel()
+
+	added, removed, changed := MapDiff(
+		map[string]int{"a": 1, "b": 2, "c": 3},
+		map[string]int{"b": 2, "c": 4, "d": 5},
+	)
+	require.Equal(t, map[string]int{"d": 5}, added)
+	require.Equal(t, map[string]int{"a": 1}, removed)
+	require.Equal(t, map[string][2]int{"c": {3, 4}}, changed)
+
+	added, removed, changed = MapDiff(map[string]int{"a": 1}, map[string]int{"a": 1})
+	require.Equal(t, map[string]int{}, added)
+	require.Equal(t, map[string]int{}, removed)
+	require.Equal(t, map[string][2]int{}, changed)
+}
+
+func TestMapToSortedSlice(t *testing.T) {
+	t.Parallel()
+
+	res1 := MapToSortedSlice(map[string]int{"c": 3, "a": 1, "d": 4, "b": 2}, func(key string, value int) string {
+		return fmt.Sprintf("%s-%d", key, value)
+	})
+	res2 := MapToSortedSlice(map[int]int{}, func(key int, value int) int {
+		return value
+	})
+
+	require.Equal(t, []string{"a-1", "b-2", "c-3", "d-4"}, res1)
+	require.Equal(t, []int{}, res2)
+}
@@ -0,0 +1,21 @@
+package util
+
+import "github.com/rambollwong/rainbowcat/types"
+
+// SafeDivide returns a / b and true, or the zero value and false if b is zero,
+// instead of panicking on integers or producing Inf/NaN on floats.
+func SafeDivide[T types.Number](a, b T) (T, bool) {
+	if b == 0 {
+		var zero T
+		return zero, false
+	}
+	return a / b, true
+}
+
+// Percentage returns part as a percentage of whole, or 0 if whole is zero.
+func Percentage(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return part / whole * 100
+}
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeDivide(t *testing.T) {
+	t.Parallel()
+
+	res1, ok1 := SafeDivide(7, 2)
+	res2, ok2 := SafeDivide(7, 0)
+	res3, ok3 := SafeDivide(7.0, 2.0)
+	res4, ok4 := SafeDivide(0.0, 0.0)
+
+	require.True(t, ok1)
+	require.Equal(t, 3, res1)
+	require.False(t, ok2)
+	require.Equal(t, 0, res2)
+	require.True(t, ok3)
+	require.Equal(t, 3.5, res3)
+	require.False(t, ok4)
+	require.False(t, math.IsNaN(res4))
+}
+
+func TestPercentage(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, 25.0, Percentage(1, 4))
+	require.Equal(t, 0.0, Percentage(1, 0))
+	require.Equal(t, 0.0, Percentage(0, 0))
+}
@@ -0,0 +1,60 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoizeTTL returns a function that caches the results of fn per key for the duration ttl.
+// After a result has expired, the next call for its key invokes fn again.
+// Concurrent calls for a key that is not cached share a single invocation of fn.
+// Errors are not cached. Expired results are only replaced, never evicted,
+// so the memory used grows with the number of distinct keys.
+func MemoizeTTL[K comparable, V any](ttl time.Duration, fn func(K) (V, error)) func(K) (V, error) {
+	return memoizeTTL(ttl, fn, time.Now)
+}
+
+// memoizedValue is a result cached by MemoizeTTL.
+type memoizedValue[V any] struct {
+	v         V
+	expiresAt time.Time
+}
+
+// memoizeTTL is MemoizeTTL with the clock injected.
+func memoizeTTL[K comparable, V any](ttl time.Duration, fn func(K) (V, error), now func() time.Time) func(K) (V, error) {
+	var (
+		mu     sync.RWMutex
+		values = make(map[K]memoizedValue[V])
+		sf     SingleFlight[K, V]
+	)
+	lookup := func(k K) (V, bool) {
+		mu.RLock()
+		defer mu.RUnlock()
+		mv, ok := values[k]
+		if !ok || !now().Before(mv.expiresAt) {
+			var zero V
+			return zero, false
+		}
+		return mv.v, true
+	}
+	return func(k K) (V, error) {
+		if v, ok := lookup(k); ok {
+			return v, nil
+		}
+		v, err, _ := sf.Do(k, func() (V, error) {
+			// Another call may have refreshed the value while waiting for the single flight
+			if v, ok := lookup(k); ok {
+				return v, nil
+			}
+			v, err := fn(k)
+			if err != nil {
+				return v, err
+			}
+			mu.Lock()
+			values[k] = memoizedValue[V]{v: v, expiresAt: now().Add(ttl)}
+			mu.Unlock()
+			return v, nil
+		})
+		return v, err
+	}
+}
@@ -0,0 +1,91 @@
+package util
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoizeTTL(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		now   = time.Unix(1000, 0)
+		calls = map[string]int{}
+	)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	errFailed := errors.New("failed")
+	f := memoizeTTL(time.Minute, func(k string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[k]++
+		if k == "bad" {
+			return "", errFailed
+		}
+		return k + "-" + strconv.Itoa(calls[k]), nil
+	}, clock)
+
+	v, err := f("a")
+	require.NoError(t, err)
+	require.Equal(t, "a-1", v)
+	v, err = f("a")
+	require.NoError(t, err)
+	require.Equal(t, "a-1", v)
+	_, err = f("b")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 1}, calls)
+
+	// The value is refreshed only after the TTL elapses
+	mu.Lock()
+	now = now.Add(59 * time.Second)
+	mu.Unlock()
+	v, _ = f("a")
+	require.Equal(t, "a-1", v)
+	mu.Lock()
+	now = now.Add(time.Second)
+	mu.Unlock()
+	v, _ = f("a")
+	require.Equal(t, "a-2", v)
+	require.Equal(t, map[string]int{"a": 2, "b": 1}, calls)
+
+	// Errors are not cached
+	_, err = f("bad")
+	require.ErrorIs(t, err, errFailed)
+	_, err = f("bad")
+	require.ErrorIs(t, err, errFailed)
+	require.Equal(t, 2, calls["bad"])
+}
+
+func TestMemoizeTTLSingleFlight(t *testing.T) {
+	t.Parallel()
+
+	var calls int64
+	f := MemoizeTTL(time.Minute, func(k int) (int, error) {
+		atomic.AddInt64(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return k * 2, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := f(21)
+			require.NoError(t, err)
+			require.Equal(t, 42, v)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int64(1), atomic.LoadInt64(&calls))
+}
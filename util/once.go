@@ -0,0 +1,35 @@
+package util
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// OnceSuccess returns a function that invokes fn until it succeeds, e.g., for lazy connection setup.
+// Unlike sync.Once, only a successful result is cached, and fn is retried on subsequent calls if it returned an error.
+// Concurrent calls are serialized, so fn is never invoked concurrently.
+func OnceSuccess[T any](fn func() (T, error)) func() (T, error) {
+	var (
+		mu   sync.Mutex
+		done atomic.Bool
+		v    T
+	)
+	return func() (T, error) {
+		if done.Load() {
+			return v, nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if done.Load() {
+			return v, nil
+		}
+		res, err := fn()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		v = res
+		done.Store(true)
+		return v, nil
+	}
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnceSuccess(t *testing.T) {
+	t.Parallel()
+
+	errConnect := errors.New("connect failed")
+	calls := 0
+	connect := OnceSuccess(func() (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errConnect
+		}
+		return "conn", nil
+	})
+
+	for i := 1; i < 3; i++ {
+		v, err := connect()
+		require.ErrorIs(t, err, errConnect)
+		require.Empty(t, v)
+		require.Equal(t, i, calls)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := connect()
+			require.NoError(t, err)
+			require.Equal(t, "conn", v)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 3, calls)
+}
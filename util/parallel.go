@@ -0,0 +1,26 @@
+package util
+
+import (
+	"errors"
+	"sync"
+)
+
+// Parallel runs all functions concurrently and waits for all of them to return.
+// A failing function does not stop the others.
+// It returns the errors of the failing functions joined by errors.Join in the order of fns, or nil if none failed.
+func Parallel(fns ...func() error) error {
+	errs := make([]error, len(fns))
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		if fn == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, fn func() error) {
+			defer wg.Done()
+			errs[i] = fn()
+		}(i, fn)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallel(t *testing.T) {
+	t.Parallel()
+
+	var ran int64
+	err1 := errors.New("err1")
+	err2 := errors.New("err2")
+	err := Parallel(
+		func() error {
+			atomic.AddInt64(&ran, 1)
+			return err1
+		},
+		func() error {
+			atomic.AddInt64(&ran, 1)
+			return nil
+		},
+		func() error {
+			atomic.AddInt64(&ran, 1)
+			return err2
+		},
+	)
+	require.Equal(t, int64(3), atomic.LoadInt64(&ran))
+	require.ErrorIs(t, err, err1)
+	require.ErrorIs(t, err, err2)
+	require.Equal(t, "err1\nerr2", err.Error())
+
+	require.NoError(t, Parallel(func() error { return nil }, nil))
+	require.NoError(t, Parallel())
+}
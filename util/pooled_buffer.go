@@ -0,0 +1,157 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/rambollwong/rainbowcat/pool"
+)
+
+// ErrBufferReleased is returned when using a PooledBuffer after it has been released.
+var ErrBufferReleased = errors.New("buffer released")
+
+var _ io.ReadWriteCloser = (*PooledBuffer)(nil)
+
+// PooledBuffer is an io.ReadWriter backed by a bytes slice borrowed from a BytesPool.
+// Call Release (or Close) when done to give the bytes slice back to the pool.
+// After that, the buffer must not be used, and slices returned by Bytes must not be retained.
+// PooledBuffer is not safe for concurrent use.
+type PooledBuffer struct {
+	bz  *[]byte
+	off int
+
+	put func(bz *[]byte)
+}
+
+// NewPooledBuffer creates a new PooledBuffer borrowing its bytes slice from the given pool.
+// If p is nil, the global bytes pool is used.
+func NewPooledBuffer(p *pool.BytesPool) *PooledBuffer {
+	if p != nil {
+		return &PooledBuffer{bz: p.Get(), put: p.Put}
+	}
+	return &PooledBuffer{bz: pool.BytesPoolGet(), put: pool.BytesPoolPut}
+}
+
+// Write appends the contents of bz to the buffer, growing it as needed.
+func (b *PooledBuffer) Write(bz []byte) (n int, err error) {
+	if b.bz == nil {
+		return 0, ErrBufferReleased
+	}
+	*b.bz = append(*b.bz, bz...)
+	return len(bz), nil
+}
+
+// Read reads the next len(bz) unread bytes from the buffer, or until the buffer is drained.
+// It returns io.EOF if the buffer has no unread bytes.
+func (b *PooledBuffer) Read(bz []byte) (n int, err error) {
+	if b.bz == nil {
+		return 0, ErrBufferReleased
+	}
+	if b.off >= len(*b.bz) {
+		if len(bz) == 0 {
+			return 0, nil
+		}
+		return 0, io.EOF
+	}
+	n = copy(bz, (*b.bz)[b.off:])
+	b.off += n
+	return n, nil
+}
+
+// Bytes returns the unread portion of the buffer.
+// The slice is only valid until the next modification or the release of the buffer.
+func (b *PooledBuffer) Bytes() []byte {
+	if b.bz == nil {
+		return nil
+	}
+	return (*b.bz)[b.off:]
+}
+
+// Len returns the number of unread bytes of the buffer.
+func (b *PooledBuffer) Len() int {
+	if b.bz == nil {
+		return 0
+	}
+	return len(*b.bz) - b.off
+}
+
+// Reset empties the buffer but keeps the underlying storage for reuse.
+func (b *PooledBuffer) Reset() {
+	if b.bz != nil {
+		*b.bz = (*b.bz)[:0]
+	}
+	b.off = 0
+}
+
+// Release gives the bytes slice back to the pool. Calling Release more than once is a no-op.
+func (b *PooledBuffer) Release() {
+	if b.bz == nil {
+		return
+	}
+	b.put(b.bz)
+	b.bz = nil
+	b.off = 0
+}
+
+// Close releases the buffer, it always returns nil.
+func (b *PooledBuffer) Close() error {
+	b.Release()
+	return nil
+}
+
+// ReadAllPooled reads from r until an error or EOF like io.ReadAll,
+// but reads into a bytes slice borrowed from the global bytes pool, growing it as needed.
+// The returned bytes slice is owned by the caller, who should give it back with pool.BytesPoolPut when done,
+// and must not use it, or any slice sharing its backing array, afterwards.
+// On error, the bytes slice is given back to the pool and nil is returned with the error.
+func ReadAllPooled(r io.Reader) (*[]byte, error) {
+	bz := pool.BytesPoolGet()
+	b := (*bz)[:0]
+	for {
+		if len(b) == cap(b) {
+			// Let append pick the new capacity
+			b = append(b, 0)[:len(b)]
+		}
+		n, err := r.Read(b[len(b):cap(b)])
+		b = b[:len(b)+n]
+		if err != nil {
+			*bz = b
+			if errors.Is(err, io.EOF) {
+				return bz, nil
+			}
+			pool.BytesPoolPut(bz)
+			return nil, err
+		}
+	}
+}
+
+// BytesReadSeeker returns an io.ReadSeeker reading from b.
+// b must not be modified while the reader is in use.
+func BytesReadSeeker(b []byte) io.ReadSeeker {
+	return bytes.NewReader(b)
+}
+
+// pooledBytesReadSeeker is an io.ReadSeekCloser reading from a bytes slice borrowed from the global bytes pool.
+type pooledBytesReadSeeker struct {
+	*bytes.Reader
+	bz *[]byte
+}
+
+// Close gives the bytes slice back to the global pool. Calling Close more than once is a no-op.
+func (r *pooledBytesReadSeeker) Close() error {
+	if r.bz == nil {
+		return nil
+	}
+	pool.BytesPoolPut(r.bz)
+	r.bz = nil
+	r.Reader.Reset(nil)
+	return nil
+}
+
+// PooledBytesReadSeeker returns an io.ReadSeekCloser reading from a bytes slice borrowed from the global bytes pool,
+// e.g. one returned by ReadAllPooled. The reader takes the ownership of bz:
+// Close gives it back to the pool, after which bz must not be used.
+func PooledBytesReadSeeker(bz *[]byte) io.ReadSeekCloser {
+	return &pooledBytesReadSeeker{Reader: bytes.NewReader(*bz), bz: bz}
+}
@@ -0,0 +1,117 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/rambollwong/rainbowcat/pool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPooledBuffer(t *testing.T) {
+	t.Parallel()
+
+	b := NewPooledBuffer(pool.NewBytesPool(4, 1024))
+	data := []byte("Hello, World!")
+	n, err := b.Write(data)
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	require.Equal(t, len(data), b.Len())
+
+	read, err := io.ReadAll(b)
+	require.NoError(t, err)
+	require.Equal(t, data, read)
+	require.Equal(t, 0, b.Len())
+
+	b.Reset()
+	_, _ = b.Write([]byte("again"))
+	require.Equal(t, []byte("again"), b.Bytes())
+
+	// Release gives the borrowed bytes slice back to the pool once
+	var released []*[]byte
+	borrowed, put := b.bz, b.put
+	b.put = func(bz *[]byte) {
+		released = append(released, bz)
+		put(bz)
+	}
+	require.NoError(t, b.Close())
+	b.Release()
+	require.Len(t, released, 1)
+	require.Same(t, borrowed, released[0])
+	_, err = b.Write(data)
+	require.ErrorIs(t, err, ErrBufferReleased)
+	_, err = b.Read(make([]byte, 1))
+	require.ErrorIs(t, err, ErrBufferReleased)
+	require.Nil(t, b.Bytes())
+
+	// The global pool is used when no pool is given
+	g := NewPooledBuffer(nil)
+	defer g.Release()
+	_, err = io.Copy(g, bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, data, g.Bytes())
+}
+
+func TestReadAllPooled(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("0123456789"), pool.DefaultNewBytesCap)
+	bz, err := ReadAllPooled(iotest.HalfReader(bytes.NewReader(data)))
+	require.NoError(t, err)
+	require.Equal(t, data, *bz)
+	pool.BytesPoolPut(bz)
+
+	bz, err = ReadAllPooled(bytes.NewReader(nil))
+	require.NoError(t, err)
+	require.Empty(t, *bz)
+	pool.BytesPoolPut(bz)
+
+	bz, err = ReadAllPooled(iotest.TimeoutReader(bytes.NewReader(data)))
+	require.ErrorIs(t, err, iotest.ErrTimeout)
+	require.Nil(t, bz)
+}
+
+func TestBytesReadSeeker(t *testing.T) {
+	t.Parallel()
+
+	rs := BytesReadSeeker([]byte("Hello, World!"))
+	buf := make([]byte, 5)
+	n, err := rs.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "Hello", string(buf[:n]))
+
+	pos, err := rs.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), pos)
+	rest, err := io.ReadAll(rs)
+	require.NoError(t, err)
+	require.Equal(t, "World!", string(rest))
+
+	_, err = rs.Seek(-6, io.SeekEnd)
+	require.NoError(t, err)
+	n, err = rs.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "World", string(buf[:n]))
+}
+
+func TestPooledBytesReadSeeker(t *testing.T) {
+	t.Parallel()
+
+	bz, err := ReadAllPooled(bytes.NewReader([]byte("Hello, World!")))
+	require.NoError(t, err)
+
+	rsc := PooledBytesReadSeeker(bz)
+	_, err = rsc.Seek(7, io.SeekStart)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(rsc)
+	require.NoError(t, err)
+	require.Equal(t, "World!", string(rest))
+
+	require.NoError(t, rsc.Close())
+	require.NoError(t, rsc.Close())
+	n, err := rsc.Read(make([]byte, 1))
+	require.Equal(t, 0, n)
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,56 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	panicHandlerMu sync.RWMutex
+	panicHandler   = defaultPanicHandler
+)
+
+// defaultPanicHandler prints the recovered value and the stack trace to stderr.
+func defaultPanicHandler(recovered any) {
+	_, _ = fmt.Fprintf(os.Stderr, "recovered from panic: %v\n%s", recovered, debug.Stack())
+}
+
+// SetPanicHandler sets the handler invoked with the recovered value when a function run by SafeGo panics.
+// If handler is nil, the default handler which prints the panic to stderr is restored.
+func SetPanicHandler(handler func(recovered any)) {
+	panicHandlerMu.Lock()
+	defer panicHandlerMu.Unlock()
+	if handler == nil {
+		handler = defaultPanicHandler
+	}
+	panicHandler = handler
+}
+
+// SafeGo runs fn in a new goroutine, recovering any panic and passing it to the panic handler.
+func SafeGo(fn func()) {
+	go func() {
+		defer recoverPanic()
+		fn()
+	}()
+}
+
+// SafeGoCtx runs fn with ctx in a new goroutine, recovering any panic and passing it to the panic handler.
+func SafeGoCtx(ctx context.Context, fn func(ctx context.Context)) {
+	go func() {
+		defer recoverPanic()
+		fn(ctx)
+	}()
+}
+
+// recoverPanic recovers a panic and passes it to the panic handler.
+func recoverPanic() {
+	if r := recover(); r != nil {
+		panicHandlerMu.RLock()
+		handler := panicHandler
+		panicHandlerMu.RUnlock()
+		handler(r)
+	}
+}
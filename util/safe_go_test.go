@@ -0,0 +1,39 @@
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeGo(t *testing.T) {
+	recoveredC := make(chan any, 1)
+	SetPanicHandler(func(recovered any) {
+		recoveredC <- recovered
+	})
+	defer SetPanicHandler(nil)
+
+	SafeGo(func() {
+		panic("boom")
+	})
+	select {
+	case r := <-recoveredC:
+		require.Equal(t, "boom", r)
+	case <-time.After(time.Second):
+		t.Fatal("panic handler was not invoked")
+	}
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	SafeGoCtx(ctx, func(ctx context.Context) {
+		panic(ctx.Value(ctxKey{}))
+	})
+	select {
+	case r := <-recoveredC:
+		require.Equal(t, "value", r)
+	case <-time.After(time.Second):
+		t.Fatal("panic handler was not invoked")
+	}
+}
@@ -0,0 +1,73 @@
+package util
+
+import "sync"
+
+// singleFlightCall represents an in-flight or completed SingleFlight.Do call.
+type singleFlightCall[V any] struct {
+	wg    sync.WaitGroup
+	val   V
+	err   error
+	dups  int
+	panic any
+}
+
+// SingleFlight coalesces concurrent calls for the same key, so that the function runs once
+// and all callers waiting for that key receive the same result.
+// The zero value is ready to use.
+type SingleFlight[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*singleFlightCall[V]
+}
+
+// Do executes fn for the key, making sure that only one execution is in flight for a given key at a time.
+// If a duplicate call comes in, the duplicate caller waits for the original to complete and receives the same results.
+// The shared return value reports whether the result was given to multiple callers.
+// If fn panics, the panic is propagated to all callers.
+func (g *SingleFlight[K, V]) Do(key K, fn func() (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*singleFlightCall[V])
+	}
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		if c.panic != nil {
+			panic(c.panic)
+		}
+		return c.val, c.err, true
+	}
+	c := &singleFlightCall[V]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	func() {
+		defer func() {
+			c.panic = recover()
+		}()
+		c.val, c.err = fn()
+	}()
+
+	g.mu.Lock()
+	// The call may have been forgotten and replaced by a newer one
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	shared = c.dups > 0
+	g.mu.Unlock()
+	c.wg.Done()
+
+	if c.panic != nil {
+		panic(c.panic)
+	}
+	return c.val, c.err, shared
+}
+
+// Forget forgets the in-flight call for the key, so that the next Do for the key executes fn
+// instead of waiting for the earlier call.
+func (g *SingleFlight[K, V]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
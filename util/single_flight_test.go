@@ -0,0 +1,114 @@
+package util
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingleFlight(t *testing.T) {
+	t.Parallel()
+
+	var g SingleFlight[string, int]
+	var calls int64
+	release := make(chan struct{})
+
+	n := 10
+	var wg sync.WaitGroup
+	var sharedCount int64
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, shared := g.Do("key", func() (int, error) {
+				atomic.AddInt64(&calls, 1)
+				<-release
+				return 42, nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, 42, v)
+			if shared {
+				atomic.AddInt64(&sharedCount, 1)
+			}
+		}()
+	}
+	// Wait for all callers to join the in-flight call
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		c, ok := g.calls["key"]
+		return ok && c.dups == n-1
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int64(1), atomic.LoadInt64(&calls))
+	require.Equal(t, int64(n), atomic.LoadInt64(&sharedCount))
+
+	// A later call for the same key runs fn again
+	v, err, shared := g.Do("key", func() (int, error) {
+		return 0, errors.New("failed")
+	})
+	require.Error(t, err)
+	require.Equal(t, 0, v)
+	require.False(t, shared)
+}
+
+func TestSingleFlightForgetInFlight(t *testing.T) {
+	t.Parallel()
+
+	var (
+		g     SingleFlight[string, int]
+		calls atomic.Int32
+	)
+	releaseC := make(chan struct{})
+	fn := func() (int, error) {
+		n := calls.Add(1)
+		<-releaseC
+		return int(n), nil
+	}
+
+	firstC := make(chan int)
+	go func() {
+		v, _, _ := g.Do("key", fn)
+		firstC <- v
+	}()
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	g.Forget("key")
+
+	// The second call executes fn again instead of waiting for the forgotten one
+	secondC := make(chan int)
+	go func() {
+		v, _, _ := g.Do("key", fn)
+		secondC <- v
+	}()
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Finishing the forgotten call must not remove the newer in-flight call
+	releaseC <- struct{}{}
+	require.Equal(t, 1, <-firstC)
+
+	type result struct {
+		v      int
+		shared bool
+	}
+	thirdC := make(chan result)
+	go func() {
+		v, _, shared := g.Do("key", fn)
+		thirdC <- result{v: v, shared: shared}
+	}()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["key"] != nil && g.calls["key"].dups == 1
+	}, time.Second, time.Millisecond)
+	close(releaseC)
+
+	require.Equal(t, 2, <-secondC)
+	require.Equal(t, result{v: 2, shared: true}, <-thirdC)
+	require.Equal(t, int32(2), calls.Load())
+}
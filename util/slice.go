@@ -1,7 +1,12 @@
 package util
 
 import (
+	"fmt"
+	"iter"
 	"math/rand"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/rambollwong/rainbowcat/types"
 )
@@ -26,6 +31,61 @@ func SliceContainsOneBy[T any](collection []T, predicate func(item T) bool) bool
 	return false
 }
 
+// SliceFind returns the first element predicate returns true for and true.
+// If no element matches, the zero value and false are returned.
+func SliceFind[T any](collection []T, predicate func(item T) bool) (T, bool) {
+	for _, item := range collection {
+		if predicate(item) {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// SliceFirstOr returns the first element in the collection that satisfies the predicate function.
+// If no element satisfies the predicate, the fallback is returned.
+func SliceFirstOr[T any](collection []T, predicate func(item T) bool, fallback T) T {
+	if item, ok := SliceFind(collection, predicate); ok {
+		return item
+	}
+	return fallback
+}
+
+// SliceMaxBy returns the element with the highest value returned by rank and true.
+// If several elements share the highest value, the first one is returned.
+// If the collection is empty, the zero value and false are returned.
+func SliceMaxBy[T any](collection []T, rank func(item T) float64) (T, bool) {
+	if len(collection) == 0 {
+		var zero T
+		return zero, false
+	}
+	result, resultRank := collection[0], rank(collection[0])
+	for _, item := range collection[1:] {
+		if r := rank(item); r > resultRank {
+			result, resultRank = item, r
+		}
+	}
+	return result, true
+}
+
+// SliceMinBy returns the element with the lowest value returned by rank and true.
+// If several elements share the lowest value, the first one is returned.
+// If the collection is empty, the zero value and false are returned.
+func SliceMinBy[T any](collection []T, rank func(item T) float64) (T, bool) {
+	if len(collection) == 0 {
+		var zero T
+		return zero, false
+	}
+	result, resultRank := collection[0], rank(collection[0])
+	for _, item := range collection[1:] {
+		if r := rank(item); r < resultRank {
+			result, resultRank = item, r
+		}
+	}
+	return result, true
+}
+
 // SliceContainsAll returns true if all elements of a subset are contained into a collection or if the subset is empty.
 func SliceContainsAll[T comparable](collection []T, subset []T) bool {
 	collectionMap := make(map[T]struct{}, len(subset))
@@ -135,6 +195,63 @@ func SliceDifference[T comparable](list1, list2 []T) ([]T, []T) {
 	return SliceExcludeAll(list1, list2...), SliceExcludeAll(list2, list1...)
 }
 
+// SliceDifferenceBy returns the difference between two collections by the key derived from each element by keyFn.
+// The first value is the collection of element whose key is absent of list2.
+// The second value is the collection of element whose key is absent of list1.
+func SliceDifferenceBy[T any, K comparable](list1, list2 []T, keyFn func(item T) K) (onlyIn1, onlyIn2 []T) {
+	keys1 := make(map[K]struct{}, len(list1))
+	for _, item := range list1 {
+		keys1[keyFn(item)] = struct{}{}
+	}
+	keys2 := make(map[K]struct{}, len(list2))
+	for _, item := range list2 {
+		keys2[keyFn(item)] = struct{}{}
+	}
+	onlyIn1 = make([]T, 0)
+	for _, item := range list1 {
+		if _, ok := keys2[keyFn(item)]; !ok {
+			onlyIn1 = append(onlyIn1, item)
+		}
+	}
+	onlyIn2 = make([]T, 0)
+	for _, item := range list2 {
+		if _, ok := keys1[keyFn(item)]; !ok {
+			onlyIn2 = append(onlyIn2, item)
+		}
+	}
+	return onlyIn1, onlyIn2
+}
+
+// SliceDiffCounts counts the distinct elements only in new (added), only in old (removed), and in both (common),
+// without allocating the difference slices. Duplicate elements are counted once.
+func SliceDiffCounts[T comparable](old, new []T) (added, removed, common int) {
+	oldSet := make(map[T]struct{}, len(old))
+	for _, item := range old {
+		oldSet[item] = struct{}{}
+	}
+	newSet := make(map[T]struct{}, len(new))
+	for _, item := range new {
+		if _, ok := newSet[item]; ok {
+			continue
+		}
+		newSet[item] = struct{}{}
+		if _, ok := oldSet[item]; ok {
+			common++
+		} else {
+			added++
+		}
+	}
+	removed = len(oldSet) - common
+	return added, removed, common
+}
+
+// SliceSymmetricDifference returns the distinct elements present in exactly one of the two collections.
+// The elements only in a come first, followed by the elements only in b, each in the order of their first appearance.
+func SliceSymmetricDifference[T comparable](a, b []T) []T {
+	onlyInA, onlyInB := SliceDifference(a, b)
+	return SliceUnion(onlyInA, onlyInB)
+}
+
 // SliceUnion returns all distinct elements from given collections.
 // result returns will not change the order of elements relatively.
 func SliceUnion[T comparable](lists ...[]T) []T {
@@ -180,6 +297,31 @@ func SliceFilter[T any](collection []T, predicate func(index int, item T) bool)
 	return result
 }
 
+// SliceEveryI returns true if predicate returns truthy for all elements of collection or if collection is empty.
+func SliceEveryI[T any](collection []T, predicate func(index int, item T) bool) bool {
+	for i, item := range collection {
+		if !predicate(i, item) {
+			return false
+		}
+	}
+	return true
+}
+
+// SliceSomeI returns true if predicate returns truthy for at least one element of collection.
+func SliceSomeI[T any](collection []T, predicate func(index int, item T) bool) bool {
+	for i, item := range collection {
+		if predicate(i, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// SliceNoneI returns true if predicate returns truthy for no element of collection or if collection is empty.
+func SliceNoneI[T any](collection []T, predicate func(index int, item T) bool) bool {
+	return !SliceSomeI(collection, predicate)
+}
+
 // SliceTransformType manipulates a slice and transforms it to a slice of another type.
 func SliceTransformType[T any, R any](collection []T, transformer func(index int, item T) R) []R {
 	result := make([]R, 0, len(collection))
@@ -228,6 +370,81 @@ func SliceFlattenTransformType[T any, R any](collection []T, flattenTransformer
 	return result
 }
 
+// SliceFlatMap transforms each element of the collection into a slice and flattens the results into a single slice.
+// It is SliceFlattenTransformType without the index, and a `nil` slice adds no value to the final slice.
+func SliceFlatMap[T any, R any](collection []T, fn func(item T) []R) []R {
+	return SliceFlattenTransformType(collection, func(_ int, item T) []R {
+		return fn(item)
+	})
+}
+
+// SliceFlattenTransformTypeE is like SliceFlattenTransformType, but the flatten transformer function may return an error.
+// It stops at the first error and returns the results accumulated so far together with the error.
+func SliceFlattenTransformTypeE[T any, R any](
+	collection []T,
+	flattenTransformer func(index int, item T) ([]R, error),
+) ([]R, error) {
+	result := make([]R, 0, len(collection))
+	for i, item := range collection {
+		r, err := flattenTransformer(i, item)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, r...)
+	}
+	return result, nil
+}
+
+// SliceProcessAll calls fn for every element of the collection, never stopping early.
+// It returns the error of each element at the same index, which is nil if fn succeeded.
+func SliceProcessAll[T any](collection []T, fn func(index int, item T) error) []error {
+	errs := make([]error, len(collection))
+	for i, item := range collection {
+		errs[i] = fn(i, item)
+	}
+	return errs
+}
+
+// SliceAssertType asserts each element of the collection to type T.
+// It returns the elements that are of type T, in order, and the indices of the elements that are not.
+// Both returned slices are non-nil.
+func SliceAssertType[T any](collection []any) (result []T, failedIndices []int) {
+	result = make([]T, 0, len(collection))
+	failedIndices = make([]int, 0)
+	for i, item := range collection {
+		if r, ok := item.(T); ok {
+			result = append(result, r)
+		} else {
+			failedIndices = append(failedIndices, i)
+		}
+	}
+	return result, failedIndices
+}
+
+// SliceDeref converts a slice of pointers to a slice of the values they point to.
+// The zero value is used for nil pointers.
+func SliceDeref[T any](collection []*T) []T {
+	result := make([]T, len(collection))
+	for i, item := range collection {
+		if item != nil {
+			result[i] = *item
+		}
+	}
+	return result
+}
+
+// SlicePtr converts a slice of values to a slice of pointers.
+// Each pointer points to a copy of the element, so the collection is not aliased.
+func SlicePtr[T any](collection []T) []*T {
+	values := make([]T, len(collection))
+	copy(values, collection)
+	result := make([]*T, len(collection))
+	for i := range values {
+		result[i] = &values[i]
+	}
+	return result
+}
+
 // SliceReduce reduces collection to a value which is the accumulated result of running each element in collection
 // through accumulator, where each successive invocation is supplied the return value of the previous.
 func SliceReduce[T any, R any](collection []T, accumulator func(agg R, item T, index int) R, initial R) R {
@@ -256,6 +473,25 @@ func SliceGroupBy[T any, U comparable](collection []T, iteratee func(item T) U)
 	return result
 }
 
+// GroupByKeysSeparator is the separator used by SliceGroupByKeys to join the parts of a composite group key.
+const GroupByKeysSeparator = "|"
+
+// SliceGroupByKeys is like SliceGroupBy, but the group key is composed of the results of running each element
+// through keyFns, joined by GroupByKeysSeparator.
+// The parts are joined as is, so they should not contain the separator, otherwise distinct keys may collide.
+func SliceGroupByKeys[T any](collection []T, keyFns ...func(item T) string) map[string][]T {
+	result := map[string][]T{}
+	parts := make([]string, len(keyFns))
+	for _, item := range collection {
+		for i, keyFn := range keyFns {
+			parts[i] = keyFn(item)
+		}
+		key := strings.Join(parts, GroupByKeysSeparator)
+		result[key] = append(result[key], item)
+	}
+	return result
+}
+
 // SliceOrderedGroupBy returns an array of elements split into groups. The order of grouped values is
 // determined by the order they occur in collection. The grouping is generated from the results
 // of running each element of collection through iteratee.
@@ -296,6 +532,60 @@ func SliceCutChunks[T any](collection []T, size int) [][]T {
 	return result
 }
 
+// SliceEachCons calls fn for each window of size consecutive elements, sliding by one element,
+// without allocating a slice of windows. Each window is a view of the collection capped at its length,
+// so fn should copy it if it needs to retain it. If size is greater than the length of the collection, fn is never called.
+func SliceEachCons[T any](collection []T, size int, fn func(window []T)) {
+	if size <= 0 {
+		panic("Size parameter must be greater than 0")
+	}
+	for i := 0; i+size <= len(collection); i++ {
+		fn(collection[i : i+size : i+size])
+	}
+}
+
+// SliceSplitInto returns an array of elements split into exactly n groups of near-equal length.
+// The remaining elements are distributed into the earliest groups, so the lengths differ by at most one.
+// If n is greater than the length of the collection, the trailing groups are empty.
+func SliceSplitInto[T any](collection []T, n int) [][]T {
+	if n <= 0 {
+		panic("N parameter must be greater than 0")
+	}
+	size, remainder := len(collection)/n, len(collection)%n
+	result := make([][]T, 0, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		end := start + size
+		if i < remainder {
+			end++
+		}
+		result = append(result, collection[start:end])
+		start = end
+	}
+	return result
+}
+
+// SlicePartitionN returns n slices, routing each element to the slice at the index returned by bucket.
+// The order of the elements is preserved within each slice.
+// It panics if n is not greater than 0 or bucket returns an index out of the range [0, n).
+func SlicePartitionN[T any](collection []T, bucket func(item T) int, n int) [][]T {
+	if n <= 0 {
+		panic("N parameter must be greater than 0")
+	}
+	result := make([][]T, n)
+	for i := range result {
+		result[i] = make([]T, 0)
+	}
+	for _, item := range collection {
+		idx := bucket(item)
+		if idx < 0 || idx >= n {
+			panic(fmt.Sprintf("bucket index %d out of range [0, %d)", idx, n))
+		}
+		result[idx] = append(result[idx], item)
+	}
+	return result
+}
+
 // SliceInterleaveFlatten round-robin alternating input slices and sequentially appending value at index into result.
 func SliceInterleaveFlatten[T any](collections ...[]T) []T {
 	if len(collections) == 0 {
@@ -328,6 +618,27 @@ func SliceInterleaveFlatten[T any](collections ...[]T) []T {
 	return result
 }
 
+// SliceInterleaveTruncate round-robin alternating input slices like SliceInterleaveFlatten,
+// but stops at the length of the shortest slice, so the tails of longer slices are dropped.
+func SliceInterleaveTruncate[T any](collections ...[]T) []T {
+	if len(collections) == 0 {
+		return []T{}
+	}
+	minSize := len(collections[0])
+	for _, c := range collections[1:] {
+		if len(c) < minSize {
+			minSize = len(c)
+		}
+	}
+	result := make([]T, 0, minSize*len(collections))
+	for i := 0; i < minSize; i++ {
+		for j := range collections {
+			result = append(result, collections[j][i])
+		}
+	}
+	return result
+}
+
 // SliceShuffle returns an array of shuffled values. Uses the Fisher-Yates shuffle algorithm.
 func SliceShuffle[T any](collection []T) []T {
 	rand.Shuffle(len(collection), func(i, j int) {
@@ -375,6 +686,19 @@ func SliceRepeatBy[T any](count int, predicate func(index int) T) []T {
 	return result
 }
 
+// SliceRepeatSlice builds a slice concatenating N copies of the collection.
+// If times <= 0, an empty slice is returned.
+func SliceRepeatSlice[T any](collection []T, times int) []T {
+	if times <= 0 {
+		return []T{}
+	}
+	result := make([]T, 0, len(collection)*times)
+	for i := 0; i < times; i++ {
+		result = append(result, collection...)
+	}
+	return result
+}
+
 // SliceToMap returns a map containing key-value pairs provided by transform function applied to elements of the given slice.
 // If any of two pairs would have the same key the last one gets added to the map.
 // The order of keys in returned map is not specified and is not guaranteed to be the same from the original array.
@@ -387,6 +711,63 @@ func SliceToMap[T any, K comparable, V any](collection []T, transform func(item
 	return result
 }
 
+// SliceToMapBy is like SliceToMap, but the key and the value are provided by two separate functions.
+// If any of two elements would have the same key the last one gets added to the map.
+func SliceToMapBy[T any, K comparable, V any](collection []T, keyFn func(item T) K, valFn func(item T) V) map[K]V {
+	result := make(map[K]V, len(collection))
+	for _, t := range collection {
+		result[keyFn(t)] = valFn(t)
+	}
+	return result
+}
+
+// SliceToMultiMap returns a map containing key-value pairs provided by transform function applied to elements of the given slice.
+// Unlike SliceToMap, values with the same key are appended to the slice of the key in the order of the collection.
+func SliceToMultiMap[T any, K comparable, V any](collection []T, transform func(item T) (K, V)) map[K][]V {
+	result := make(map[K][]V)
+	for _, t := range collection {
+		k, v := transform(t)
+		result[k] = append(result[k], v)
+	}
+	return result
+}
+
+// SliceAlignByKey aligns the elements of a and b by the keys returned by keyA and keyB, e.g., for side-by-side diffs.
+// It returns a tuple for each key present in either slice, holding the key and pointers to the matching elements,
+// and the pointer is nil if the key is absent in that slice.
+// The tuples are ordered by the first appearance of the keys in a, then in b.
+// If a key appears several times in one slice, the first element is used.
+func SliceAlignByKey[T any, U any, K comparable](
+	a []T,
+	b []U,
+	keyA func(item T) K,
+	keyB func(item U) K,
+) []types.Tuple3[K, *T, *U] {
+	result := make([]types.Tuple3[K, *T, *U], 0, len(a))
+	indices := make(map[K]int, len(a))
+	for i := range a {
+		k := keyA(a[i])
+		if _, ok := indices[k]; ok {
+			continue
+		}
+		indices[k] = len(result)
+		result = append(result, types.Tuple3[K, *T, *U]{A: k, B: &a[i]})
+	}
+	for i := range b {
+		k := keyB(b[i])
+		idx, ok := indices[k]
+		if !ok {
+			indices[k] = len(result)
+			result = append(result, types.Tuple3[K, *T, *U]{A: k, C: &b[i]})
+			continue
+		}
+		if result[idx].C == nil {
+			result[idx].C = &b[i]
+		}
+	}
+	return result
+}
+
 // SliceCutLeft drops n elements from the beginning of a slice or array.
 // The slice returned is a new slice.
 func SliceCutLeft[T any](collection []T, n int) []T {
@@ -460,6 +841,23 @@ func SliceValuesCount[T comparable](collection []T) map[T]int {
 	return result
 }
 
+// SliceMode returns the most frequently occurring element of the collection, its count and true.
+// Ties are broken by first appearance. If the collection is empty, the zero value, 0 and false are returned.
+func SliceMode[T comparable](collection []T) (T, int, bool) {
+	var mode T
+	if len(collection) == 0 {
+		return mode, 0, false
+	}
+	counts := SliceValuesCount(collection)
+	maxCount := 0
+	for _, item := range collection {
+		if c := counts[item]; c > maxCount {
+			mode, maxCount = item, c
+		}
+	}
+	return mode, maxCount, true
+}
+
 // SliceValuesCountBy counts the number of each element return from mapper function.
 // Is equivalent to chaining lo.Map and lo.CountValues.
 func SliceValuesCountBy[T any, U comparable](collection []T, mapper func(item T) U) map[U]int {
@@ -470,6 +868,65 @@ func SliceValuesCountBy[T any, U comparable](collection []T, mapper func(item T)
 	return result
 }
 
+// SliceReverseIndex maps each element of the collection to all indices where it appears, in ascending order.
+func SliceReverseIndex[T comparable](collection []T) map[T][]int {
+	result := make(map[T][]int)
+	for i, item := range collection {
+		result[item] = append(result[item], i)
+	}
+	return result
+}
+
+// SliceHistogram counts the elements of the collection by the bucket returned from bucket function,
+// and returns the buckets sorted by count descending, with ties broken by bucket key ascending.
+func SliceHistogram[T any](collection []T, bucket func(item T) string) []types.Entry[string, int] {
+	counts := SliceValuesCountBy(collection, bucket)
+	result := make([]types.Entry[string, int], 0, len(counts))
+	for k, c := range counts {
+		result = append(result, types.Entry[string, int]{Key: k, Value: c})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Value != result[j].Value {
+			return result[i].Value > result[j].Value
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// SliceRunLengthEncode encodes the consecutive runs of equal elements of the collection into (value, run length) pairs.
+// It is the canonical way to collapse adjacent duplicates while keeping their counts,
+// e.g., ["a", "a", "b"] is encoded into [("a", 2), ("b", 1)].
+func SliceRunLengthEncode[T comparable](collection []T) []types.Entry[T, int] {
+	result := make([]types.Entry[T, int], 0)
+	for _, item := range collection {
+		if last := len(result) - 1; last >= 0 && result[last].Key == item {
+			result[last].Value++
+			continue
+		}
+		result = append(result, types.Entry[T, int]{Key: item, Value: 1})
+	}
+	return result
+}
+
+// SliceRunLengthDecode decodes (value, run length) pairs produced by SliceRunLengthEncode back into a slice.
+// Pairs with non-positive run length are skipped.
+func SliceRunLengthDecode[T comparable](runs []types.Entry[T, int]) []T {
+	size := 0
+	for _, run := range runs {
+		if run.Value > 0 {
+			size += run.Value
+		}
+	}
+	result := make([]T, 0, size)
+	for _, run := range runs {
+		for i := 0; i < run.Value; i++ {
+			result = append(result, run.Key)
+		}
+	}
+	return result
+}
+
 // SliceSubset returns a copy of a slice from `offset` up to `length` elements.
 // Like `slice[start:start+length]`, but does not panic on overflow.
 func SliceSubset[T any](collection []T, offset int, length uint) []T {
@@ -524,7 +981,420 @@ func SliceReplace[T comparable](collection []T, old T, new T, n int) []T {
 	return result
 }
 
+// SliceReplaceFunc returns a copy of the slice with the first n elements predicate returns truthy for
+// replaced by the result of replacement. If n < 0, all matching elements are replaced.
+func SliceReplaceFunc[T any](collection []T, predicate func(item T) bool, replacement func(item T) T, n int) []T {
+	result := make([]T, len(collection))
+	copy(result, collection)
+	for i := range result {
+		if n == 0 {
+			break
+		}
+		if predicate(result[i]) {
+			result[i] = replacement(result[i])
+			n--
+		}
+	}
+	return result
+}
+
 // SliceReplaceAll returns a copy of the slice with all non-overlapping instances of old replaced by new.
 func SliceReplaceAll[T comparable](collection []T, old T, new T) []T {
 	return SliceReplace(collection, old, new, -1)
 }
+
+// SliceDistinctWindow returns a slice in which an element is dropped only if it appeared within
+// the last `window` kept elements, so memory is bounded by the window size.
+// A repeat beyond the window is kept. If window <= 0, no element will be dropped.
+func SliceDistinctWindow[T comparable](collection []T, window int) []T {
+	result := make([]T, 0, len(collection))
+	if window <= 0 {
+		return append(result, collection...)
+	}
+	seen := make(map[T]struct{}, window)
+	for _, item := range collection {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		result = append(result, item)
+		seen[item] = struct{}{}
+		if len(result) > window {
+			delete(seen, result[len(result)-1-window])
+		}
+	}
+	return result
+}
+
+// SliceDistinctByLast returns a duplicate-free version of the collection by the key derived from each element by keyFn,
+// in which only the last occurrence of each key is kept.
+// The kept elements preserve their relative order, i.e. the order of the last occurrences.
+func SliceDistinctByLast[T any, K comparable](collection []T, keyFn func(item T) K) []T {
+	last := make(map[K]int, len(collection))
+	for i, item := range collection {
+		last[keyFn(item)] = i
+	}
+	result := make([]T, 0, len(last))
+	for i, item := range collection {
+		if last[keyFn(item)] == i {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ReservoirSample reads items until the channel is closed and returns k uniformly random elements
+// using Algorithm R. If fewer than k elements are received, all of them are returned.
+func ReservoirSample[T any](items <-chan T, k int) []T {
+	return ReservoirSampleWithRand(items, k, nil)
+}
+
+// ReservoirSampleWithRand is like ReservoirSample but uses the given rand source.
+// If r is nil, the global rand source is used.
+func ReservoirSampleWithRand[T any](items <-chan T, k int, r *rand.Rand) []T {
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	if k < 0 {
+		k = 0
+	}
+	result := make([]T, 0, k)
+	seen := 0
+	for item := range items {
+		seen++
+		if len(result) < k {
+			result = append(result, item)
+			continue
+		}
+		if j := intn(seen); j < k {
+			result[j] = item
+		}
+	}
+	return result
+}
+
+// SliceFirstNonZero returns the first element of the collection that is not the zero value,
+// and a boolean indicating whether such an element was found.
+func SliceFirstNonZero[T comparable](collection []T) (T, bool) {
+	var zero T
+	for _, item := range collection {
+		if item != zero {
+			return item, true
+		}
+	}
+	return zero, false
+}
+
+// SliceLastNonZero returns the last element of the collection that is not the zero value,
+// and a boolean indicating whether such an element was found.
+func SliceLastNonZero[T comparable](collection []T) (T, bool) {
+	var zero T
+	for i := len(collection) - 1; i >= 0; i-- {
+		if collection[i] != zero {
+			return collection[i], true
+		}
+	}
+	return zero, false
+}
+
+// sliceIndexOfSubslice returns the index of the first contiguous run of sub within collection, or -1 if absent.
+func sliceIndexOfSubslice[T comparable](collection, sub []T) int {
+	n := len(sub)
+	for i := 0; i+n <= len(collection); i++ {
+		match := true
+		for j := 0; j < n; j++ {
+			if collection[i+j] != sub[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// SliceContainsSubslice returns true if sub appears as a contiguous ordered run within collection.
+// If sub is empty SliceContainsSubslice returns true.
+func SliceContainsSubslice[T comparable](collection, sub []T) bool {
+	return sliceIndexOfSubslice(collection, sub) >= 0
+}
+
+// SliceStartsWith returns true if the collection begins with prefix.
+// If prefix is empty SliceStartsWith returns true.
+func SliceStartsWith[T comparable](collection, prefix []T) bool {
+	if len(prefix) > len(collection) {
+		return false
+	}
+	for i := range prefix {
+		if collection[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SliceEndsWith returns true if the collection ends with suffix.
+// If suffix is empty SliceEndsWith returns true.
+func SliceEndsWith[T comparable](collection, suffix []T) bool {
+	if len(suffix) > len(collection) {
+		return false
+	}
+	offset := len(collection) - len(suffix)
+	for i := range suffix {
+		if collection[offset+i] != suffix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SliceValuesSeq returns an iterator over the elements of the collection, usable in `for range`.
+func SliceValuesSeq[T any](collection []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range collection {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SliceSeq2 returns an iterator over the index/element pairs of the collection, usable in `for range`.
+func SliceSeq2[T any](collection []T) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, item := range collection {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSeq collects all values of the iterator into a new slice.
+func CollectSeq[T any](seq iter.Seq[T]) []T {
+	result := make([]T, 0)
+	for item := range seq {
+		result = append(result, item)
+	}
+	return result
+}
+
+// SliceGroupReduce groups the elements of collection by the keys generated from keyFn,
+// and reduces each group to a value in one pass, starting each group from the initial value.
+func SliceGroupReduce[T any, K comparable, R any](
+	collection []T,
+	keyFn func(item T) K,
+	reduce func(agg R, item T) R,
+	initial R,
+) map[K]R {
+	result := map[K]R{}
+	for _, item := range collection {
+		key := keyFn(item)
+		agg, ok := result[key]
+		if !ok {
+			agg = initial
+		}
+		result[key] = reduce(agg, item)
+	}
+	return result
+}
+
+// SliceWeightedSample returns a random element of the collection, where each element is chosen
+// with a probability proportional to its weight. Non-positive weights are never chosen.
+// If the total weight is zero, it returns the zero value and false.
+func SliceWeightedSample[T any](collection []T, weight func(item T) float64) (T, bool) {
+	return SliceWeightedSampleWithRand(collection, weight, nil)
+}
+
+// SliceWeightedSampleWithRand is like SliceWeightedSample but uses the given rand source.
+// If r is nil, the global rand source is used.
+func SliceWeightedSampleWithRand[T any](collection []T, weight func(item T) float64, r *rand.Rand) (T, bool) {
+	float64n := rand.Float64
+	if r != nil {
+		float64n = r.Float64
+	}
+	cumulative := make([]float64, len(collection))
+	total := 0.0
+	for i, item := range collection {
+		if w := weight(item); w > 0 {
+			total += w
+		}
+		cumulative[i] = total
+	}
+	if total <= 0 {
+		var zero T
+		return zero, false
+	}
+	target := float64n() * total
+	i := sort.Search(len(cumulative), func(i int) bool {
+		return cumulative[i] > target
+	})
+	if i == len(cumulative) {
+		i--
+	}
+	return collection[i], true
+}
+
+// SliceSampleStable returns n elements of the collection chosen deterministically from the seed,
+// so the same seed always picks the same subset of the same collection. The picked elements keep their original order.
+// If n >= len(collection), a copy of the collection is returned. If n <= 0, an empty slice is returned.
+func SliceSampleStable[T any](collection []T, n int, seed int64) []T {
+	if n <= 0 {
+		return []T{}
+	}
+	if n >= len(collection) {
+		return append(make([]T, 0, len(collection)), collection...)
+	}
+	indices := rand.New(rand.NewSource(seed)).Perm(len(collection))[:n]
+	sort.Ints(indices)
+	result := make([]T, 0, n)
+	for _, i := range indices {
+		result = append(result, collection[i])
+	}
+	return result
+}
+
+// SliceResample resizes the collection to exactly targetLen elements, e.g., for charting time series.
+// The elements of the result are evenly spaced over the collection, keeping its first and last elements.
+// Each element of the result is interpolated by interpolate between the two nearest elements,
+// with t in [0, 1) as the relative distance from the first one,
+// or is the nearest element of the collection if interpolate is nil.
+// If the collection is empty or targetLen is not greater than 0, an empty slice is returned.
+func SliceResample[T any](collection []T, targetLen int, interpolate func(a, b T, t float64) T) []T {
+	if len(collection) == 0 || targetLen <= 0 {
+		return []T{}
+	}
+	result := make([]T, targetLen)
+	step := 0.0
+	if targetLen > 1 {
+		step = float64(len(collection)-1) / float64(targetLen-1)
+	}
+	for i := range result {
+		pos := float64(i) * step
+		if interpolate == nil {
+			result[i] = collection[min(int(pos+0.5), len(collection)-1)]
+			continue
+		}
+		lo := min(int(pos), len(collection)-1)
+		hi := min(lo+1, len(collection)-1)
+		result[i] = interpolate(collection[lo], collection[hi], pos-float64(lo))
+	}
+	return result
+}
+
+// SliceJoinToString formats each element of the collection and joins them with the separator.
+// If format is nil, elements are formatted with fmt.Sprint.
+func SliceJoinToString[T any](collection []T, sep string, format func(item T) string) string {
+	if format == nil {
+		format = func(item T) string {
+			return fmt.Sprint(item)
+		}
+	}
+	var sb strings.Builder
+	for i, item := range collection {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(format(item))
+	}
+	return sb.String()
+}
+
+// SliceRemoveFunc removes the elements predicate returns truthy for by compacting the collection in place,
+// and returns the new slice and the number of removed elements.
+// The input collection is mutated, and the elements between the new length and the old length are zeroed.
+func SliceRemoveFunc[T any](collection []T, predicate func(item T) bool) ([]T, int) {
+	kept := 0
+	for _, item := range collection {
+		if !predicate(item) {
+			collection[kept] = item
+			kept++
+		}
+	}
+	var zero T
+	for i := kept; i < len(collection); i++ {
+		collection[i] = zero
+	}
+	return collection[:kept], len(collection) - kept
+}
+
+// SliceAllUnique returns true if every element of the collection is distinct.
+// It stops at the first duplicate found.
+func SliceAllUnique[T comparable](collection []T) bool {
+	seen := make(map[T]struct{}, len(collection))
+	for _, item := range collection {
+		if _, ok := seen[item]; ok {
+			return false
+		}
+		seen[item] = struct{}{}
+	}
+	return true
+}
+
+// SliceDuplicates returns the distinct values that appear more than once in the collection,
+// in the order of their second occurrence.
+func SliceDuplicates[T comparable](collection []T) []T {
+	counts := make(map[T]int, len(collection))
+	result := make([]T, 0)
+	for _, item := range collection {
+		counts[item]++
+		if counts[item] == 2 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// SliceSortedInsert inserts element into the collection sorted by less, keeping it sorted,
+// and returns the updated slice. The position is found by binary search,
+// and element is placed after any elements equal to it.
+// Like append, the returned slice may share the underlying array of the collection.
+func SliceSortedInsert[T any](collection []T, element T, less func(a, b T) bool) []T {
+	i := sort.Search(len(collection), func(i int) bool {
+		return less(element, collection[i])
+	})
+	var zero T
+	collection = append(collection, zero)
+	copy(collection[i+1:], collection[i:])
+	collection[i] = element
+	return collection
+}
+
+// SliceEnsureCap returns the collection with its capacity grown to at least len(collection)+extra,
+// reallocating at most once, so that the next extra appends do not reallocate.
+// The length and contents are kept. If the capacity is already sufficient or extra <= 0, the collection is returned as is.
+func SliceEnsureCap[T any](collection []T, extra int) []T {
+	if extra <= 0 {
+		return collection
+	}
+	return slices.Grow(collection, extra)
+}
+
+// SliceSplitAt splits the collection at the index into two independent copies.
+// The index is clamped to [0, len(collection)], so an index beyond the length puts every element in left,
+// and a negative index puts every element in right.
+func SliceSplitAt[T any](collection []T, index int) (left, right []T) {
+	if index < 0 {
+		index = 0
+	}
+	if index > len(collection) {
+		index = len(collection)
+	}
+	left = append(make([]T, 0, index), collection[:index]...)
+	right = append(make([]T, 0, len(collection)-index), collection[index:]...)
+	return left, right
+}
+
+// SliceIndicesWhere returns the indices of all elements predicate returns truthy for.
+// If no element matches, an empty non-nil slice is returned.
+func SliceIndicesWhere[T any](collection []T, predicate func(item T) bool) []int {
+	result := make([]int, 0)
+	for i, item := range collection {
+		if predicate(item) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
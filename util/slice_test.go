@@ -1,10 +1,14 @@
 package util
 
 import (
+	"errors"
+	"math/rand"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/rambollwong/rainbowcat/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,6 +61,66 @@ func TestSliceContainsOneBy(t *testing.T) {
 	require.True(t, res4)
 }
 
+func TestSliceFind(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	users := []user{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}, {ID: 3, Name: "bob"}}
+
+	res1, ok1 := SliceFind(users, func(item user) bool {
+		return item.Name == "bob"
+	})
+	res2, ok2 := SliceFind(users, func(item user) bool {
+		return item.ID == 4
+	})
+
+	require.True(t, ok1)
+	require.Equal(t, user{ID: 2, Name: "bob"}, res1)
+	require.False(t, ok2)
+	require.Equal(t, user{}, res2)
+}
+
+func TestSliceFirstOr(t *testing.T) {
+	t.Parallel()
+
+	configs := []string{"default", "preferred", "fallback"}
+	isPreferred := func(item string) bool { return item == "preferred" }
+
+	require.Equal(t, "preferred", SliceFirstOr(configs, isPreferred, "none"))
+	require.Equal(t, "none", SliceFirstOr(configs[:1], isPreferred, "none"))
+	require.Equal(t, "none", SliceFirstOr(nil, isPreferred, "none"))
+}
+
+func TestSliceMaxByAndMinBy(t *testing.T) {
+	t.Parallel()
+
+	type product struct {
+		Name  string
+		Price float64
+	}
+
+	products := []product{{"pen", 1.5}, {"book", 12}, {"bag", 30}, {"cup", 1.5}, {"lamp", 30}}
+	price := func(item product) float64 {
+		return item.Price
+	}
+
+	res1, ok1 := SliceMaxBy(products, price)
+	res2, ok2 := SliceMinBy(products, price)
+	_, ok3 := SliceMaxBy([]product{}, price)
+	_, ok4 := SliceMinBy(nil, price)
+
+	require.True(t, ok1)
+	require.Equal(t, product{"bag", 30}, res1)
+	require.True(t, ok2)
+	require.Equal(t, product{"pen", 1.5}, res2)
+	require.False(t, ok3)
+	require.False(t, ok4)
+}
+
 func TestSliceContainsAll(t *testing.T) {
 	t.Parallel()
 
@@ -220,6 +284,61 @@ func TestSliceDifference(t *testing.T) {
 	require.Equal(t, []int{3, 4}, res8)
 }
 
+func TestSliceDiffCounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		old, new               []int
+		added, removed, common int
+	}{
+		{[]int{1, 2, 3}, []int{2, 3, 4, 5}, 2, 1, 2},
+		{[]int{1, 2}, []int{3, 4}, 2, 2, 0},
+		{[]int{1, 2}, []int{2, 1}, 0, 0, 2},
+		{[]int{1, 1, 2}, []int{2, 2, 3, 3}, 1, 1, 1},
+		{nil, []int{1}, 1, 0, 0},
+		{[]int{1}, nil, 0, 1, 0},
+	}
+	for _, test := range tests {
+		added, removed, common := SliceDiffCounts(test.old, test.new)
+		require.Equal(t, test.added, added)
+		require.Equal(t, test.removed, removed)
+		require.Equal(t, test.common, common)
+	}
+}
+
+func TestSliceSymmetricDifference(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []int{1, 5, 4}, SliceSymmetricDifference([]int{1, 2, 3, 1}, []int{5, 2, 3, 4, 5}))
+	require.Equal(t, []int{1, 2, 3, 4}, SliceSymmetricDifference([]int{1, 2}, []int{3, 4}))
+	require.Equal(t, []int{}, SliceSymmetricDifference([]int{1, 2}, []int{2, 1}))
+	require.Equal(t, []int{1}, SliceSymmetricDifference(nil, []int{1}))
+}
+
+func TestSliceDifferenceBy(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	id := func(item user) int {
+		return item.ID
+	}
+
+	res1, res2 := SliceDifferenceBy(
+		[]user{{1, "a"}, {2, "b"}, {3, "c"}},
+		[]user{{2, "B"}, {3, "C"}, {4, "d"}},
+		id,
+	)
+	res3, res4 := SliceDifferenceBy([]user{}, []user{{1, "a"}}, id)
+
+	require.Equal(t, []user{{1, "a"}}, res1)
+	require.Equal(t, []user{{4, "d"}}, res2)
+	require.Equal(t, []user{}, res3)
+	require.Equal(t, []user{{1, "a"}}, res4)
+}
+
 func TestSliceUnion(t *testing.T) {
 	t.Parallel()
 
@@ -260,6 +379,30 @@ func TestSliceFilter(t *testing.T) {
 	require.Equal(t, []int{0, 2, 4, 6}, res1)
 }
 
+func TestSliceEveryISomeINoneI(t *testing.T) {
+	t.Parallel()
+
+	// Even indices hold even values
+	evenAtEvenIndex := func(index int, item int) bool {
+		return index%2 != 0 || item%2 == 0
+	}
+	oddAtEvenIndex := func(index int, item int) bool {
+		return index%2 == 0 && item%2 != 0
+	}
+
+	require.True(t, SliceEveryI([]int{0, 1, 2, 3, 4}, evenAtEvenIndex))
+	require.False(t, SliceEveryI([]int{0, 1, 3}, evenAtEvenIndex))
+	require.True(t, SliceEveryI([]int{}, evenAtEvenIndex))
+
+	require.True(t, SliceSomeI([]int{0, 1, 3}, oddAtEvenIndex))
+	require.False(t, SliceSomeI([]int{0, 1, 2, 3}, oddAtEvenIndex))
+	require.False(t, SliceSomeI([]int{}, oddAtEvenIndex))
+
+	require.True(t, SliceNoneI([]int{0, 1, 2, 3}, oddAtEvenIndex))
+	require.False(t, SliceNoneI([]int{0, 1, 3}, oddAtEvenIndex))
+	require.True(t, SliceNoneI([]int{}, oddAtEvenIndex))
+}
+
 func TestSliceTransformType(t *testing.T) {
 	t.Parallel()
 
@@ -298,6 +441,96 @@ func TestSliceFlattenTransformType(t *testing.T) {
 	require.Equal(t, []string{"0", "1", "2", "3"}, res1)
 }
 
+func TestSliceFlatMap(t *testing.T) {
+	t.Parallel()
+
+	res := SliceFlatMap([]int{0, 1, 2, 3}, func(item int) []string {
+		if item == 0 {
+			return nil
+		}
+		return slices.Repeat([]string{strconv.Itoa(item)}, item)
+	})
+	require.Equal(t, []string{"1", "2", "2", "3", "3", "3"}, res)
+	require.Equal(t, []string{}, SliceFlatMap(nil, func(item int) []string { return []string{"x"} }))
+}
+
+func TestSliceFlattenTransformTypeE(t *testing.T) {
+	t.Parallel()
+
+	parse := func(_ int, item string) ([]int, error) {
+		result := make([]int, 0)
+		for _, s := range strings.Split(item, ",") {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, n)
+		}
+		return result, nil
+	}
+
+	res1, err1 := SliceFlattenTransformTypeE([]string{"1,2", "3", "4,5"}, parse)
+	res2, err2 := SliceFlattenTransformTypeE([]string{"1,2", "x", "4,5"}, parse)
+
+	require.NoError(t, err1)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, res1)
+	require.Error(t, err2)
+	require.Equal(t, []int{1, 2}, res2)
+}
+
+func TestSliceProcessAll(t *testing.T) {
+	t.Parallel()
+
+	errOdd := errors.New("odd")
+	processed := make([]int, 0)
+	errs := SliceProcessAll([]int{1, 2, 3, 4}, func(index int, item int) error {
+		processed = append(processed, item)
+		if item%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+
+	require.Equal(t, []int{1, 2, 3, 4}, processed)
+	require.Equal(t, []error{errOdd, nil, errOdd, nil}, errs)
+	require.Empty(t, SliceProcessAll(nil, func(index int, item int) error { return errOdd }))
+}
+
+func TestSliceAssertType(t *testing.T) {
+	t.Parallel()
+
+	res1, failed1 := SliceAssertType[int]([]any{1, "2", 3, nil, 4.0, 5})
+	res2, failed2 := SliceAssertType[string]([]any{})
+
+	require.Equal(t, []int{1, 3, 5}, res1)
+	require.Equal(t, []int{1, 3, 4}, failed1)
+	require.Empty(t, res2)
+	require.Empty(t, failed2)
+}
+
+func TestSliceDeref(t *testing.T) {
+	t.Parallel()
+
+	one, three := 1, 3
+	res1 := SliceDeref([]*int{&one, nil, &three})
+	res2 := SliceDeref([]*string{})
+
+	require.Equal(t, []int{1, 0, 3}, res1)
+	require.Equal(t, []string{}, res2)
+}
+
+func TestSlicePtr(t *testing.T) {
+	t.Parallel()
+
+	collection := []int{1, 2, 3}
+	res1 := SlicePtr(collection)
+
+	require.Len(t, res1, 3)
+	require.Equal(t, collection, SliceDeref(res1))
+	*res1[0] = 10
+	require.Equal(t, 1, collection[0])
+}
+
 func TestSliceReduce(t *testing.T) {
 	t.Parallel()
 
@@ -335,6 +568,37 @@ func TestSliceGroupBy(t *testing.T) {
 	require.Equal(t, []int{2}, res1[2])
 }
 
+func TestSliceGroupByKeys(t *testing.T) {
+	t.Parallel()
+
+	type employee struct {
+		Name string
+		Dept string
+		City string
+	}
+	dept := func(item employee) string {
+		return item.Dept
+	}
+	city := func(item employee) string {
+		return item.City
+	}
+
+	res1 := SliceGroupByKeys([]employee{
+		{"a", "eng", "sh"},
+		{"b", "eng", "bj"},
+		{"c", "ops", "sh"},
+		{"d", "eng", "sh"},
+	}, dept, city)
+	res2 := SliceGroupByKeys([]employee{}, dept, city)
+
+	require.Equal(t, map[string][]employee{
+		"eng|sh": {{"a", "eng", "sh"}, {"d", "eng", "sh"}},
+		"eng|bj": {{"b", "eng", "bj"}},
+		"ops|sh": {{"c", "ops", "sh"}},
+	}, res1)
+	require.Equal(t, map[string][]employee{}, res2)
+}
+
 func TestSliceOrderedGroupBy(t *testing.T) {
 	t.Parallel()
 
@@ -358,6 +622,57 @@ func TestSliceCutChunks(t *testing.T) {
 	require.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, res2)
 }
 
+func TestSliceEachCons(t *testing.T) {
+	t.Parallel()
+
+	windows := make([][]int, 0)
+	SliceEachCons([]int{1, 2, 3, 4, 5}, 3, func(window []int) {
+		windows = append(windows, slices.Clone(window))
+	})
+	require.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, windows)
+
+	// Moving averages
+	averages := make([]float64, 0)
+	SliceEachCons([]float64{2, 4, 6, 8}, 2, func(window []float64) {
+		averages = append(averages, (window[0]+window[1])/2)
+	})
+	require.Equal(t, []float64{3, 5, 7}, averages)
+
+	calls := 0
+	SliceEachCons([]int{1, 2}, 3, func(window []int) { calls++ })
+	require.Zero(t, calls)
+	require.Panics(t, func() { SliceEachCons([]int{1}, 0, func(window []int) {}) })
+}
+
+func TestSliceSplitInto(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceSplitInto([]int{0, 1, 2, 3, 4, 5}, 3)
+	res2 := SliceSplitInto([]int{0, 1, 2, 3, 4}, 3)
+	res3 := SliceSplitInto([]int{0, 1}, 4)
+	res4 := SliceSplitInto([]int{}, 2)
+
+	require.Equal(t, [][]int{{0, 1}, {2, 3}, {4, 5}}, res1)
+	require.Equal(t, [][]int{{0, 1}, {2, 3}, {4}}, res2)
+	require.Equal(t, [][]int{{0}, {1}, {}, {}}, res3)
+	require.Equal(t, [][]int{{}, {}}, res4)
+	require.Panics(t, func() {
+		SliceSplitInto([]int{0}, 0)
+	})
+}
+
+func TestSlicePartitionN(t *testing.T) {
+	t.Parallel()
+
+	res := SlicePartitionN([]int{5, 1, 9, 3, 7, 2, 6}, func(item int) int { return item % 3 }, 3)
+	require.Equal(t, [][]int{{9, 3, 6}, {1, 7}, {5, 2}}, res)
+
+	require.Equal(t, [][]int{{}, {}}, SlicePartitionN(nil, func(item int) int { return 0 }, 2))
+	require.Panics(t, func() { SlicePartitionN([]int{1}, func(item int) int { return 0 }, 0) })
+	require.Panics(t, func() { SlicePartitionN([]int{1}, func(item int) int { return 2 }, 2) })
+	require.Panics(t, func() { SlicePartitionN([]int{1}, func(item int) int { return -1 }, 2) })
+}
+
 func TestSliceInterleaveFlatten(t *testing.T) {
 	t.Parallel()
 
@@ -366,6 +681,20 @@ func TestSliceInterleaveFlatten(t *testing.T) {
 	require.Equal(t, []int{0, 2, 6, 1, 3, 7, 4, 8, 5}, res1)
 }
 
+func TestSliceInterleaveTruncate(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceInterleaveTruncate([]int{1, 2}, []int{10, 20, 30, 40})
+	res2 := SliceInterleaveTruncate([]int{1, 2, 3}, []int{4, 5, 6}, []int{7, 8, 9})
+	res3 := SliceInterleaveTruncate([]int{1, 2}, []int{})
+	res4 := SliceInterleaveTruncate[int]()
+
+	require.Equal(t, []int{1, 10, 2, 20}, res1)
+	require.Equal(t, []int{1, 4, 7, 2, 5, 8, 3, 6, 9}, res2)
+	require.Equal(t, []int{}, res3)
+	require.Equal(t, []int{}, res4)
+}
+
 func TestSliceShuffle(t *testing.T) {
 	t.Parallel()
 
@@ -422,6 +751,20 @@ func TestSliceRepeatBy(t *testing.T) {
 	require.Equal(t, []foo{}, res2)
 }
 
+func TestSliceRepeatSlice(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceRepeatSlice([]int{1, 2}, 3)
+	res2 := SliceRepeatSlice([]int{1, 2}, 0)
+	res3 := SliceRepeatSlice([]int{1, 2}, -1)
+	res4 := SliceRepeatSlice([]int{}, 3)
+
+	require.Equal(t, []int{1, 2, 1, 2, 1, 2}, res1)
+	require.Equal(t, []int{}, res2)
+	require.Equal(t, []int{}, res3)
+	require.Equal(t, []int{}, res4)
+}
+
 func TestSliceToMap(t *testing.T) {
 	t.Parallel()
 
@@ -432,6 +775,76 @@ func TestSliceToMap(t *testing.T) {
 	require.Equal(t, map[int]string{1: "s", 2: "ss", 3: "sss"}, res1)
 }
 
+func TestSliceToMapBy(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{1, "alice"}, {2, "bob"}, {1, "carol"}}
+
+	res1 := SliceToMapBy(users, func(item user) int {
+		return item.ID
+	}, func(item user) string {
+		return item.Name
+	})
+	res2 := SliceToMapBy([]user{}, func(item user) int {
+		return item.ID
+	}, func(item user) string {
+		return item.Name
+	})
+
+	require.Equal(t, map[int]string{1: "carol", 2: "bob"}, res1)
+	require.Equal(t, map[int]string{}, res2)
+}
+
+func TestSliceToMultiMap(t *testing.T) {
+	t.Parallel()
+
+	type order struct {
+		UserID int
+		ID     string
+	}
+
+	orders := []order{{1, "a"}, {2, "b"}, {1, "c"}, {3, "d"}, {1, "e"}}
+	res := SliceToMultiMap(orders, func(item order) (int, string) {
+		return item.UserID, item.ID
+	})
+
+	require.Equal(t, map[int][]string{1: {"a", "c", "e"}, 2: {"b"}, 3: {"d"}}, res)
+	require.Empty(t, SliceToMultiMap([]order{}, func(item order) (int, string) { return item.UserID, item.ID }))
+}
+
+func TestSliceAlignByKey(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	old := []user{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}}
+	cur := []string{"3", "2"}
+	res := SliceAlignByKey(old, cur, func(item user) int {
+		return item.ID
+	}, func(item string) int {
+		id, _ := strconv.Atoi(item)
+		return id
+	})
+
+	require.Len(t, res, 3)
+	require.Equal(t, 1, res[0].A)
+	require.Equal(t, old[0], *res[0].B)
+	require.Nil(t, res[0].C)
+	require.Equal(t, 2, res[1].A)
+	require.Equal(t, old[1], *res[1].B)
+	require.Equal(t, "2", *res[1].C)
+	require.Equal(t, 3, res[2].A)
+	require.Nil(t, res[2].B)
+	require.Equal(t, "3", *res[2].C)
+}
+
 func TestSliceCutLeft(t *testing.T) {
 	t.Parallel()
 
@@ -522,6 +935,24 @@ func TestSliceValuesCount(t *testing.T) {
 	require.Equal(t, map[int]int{}, res2)
 }
 
+func TestSliceMode(t *testing.T) {
+	t.Parallel()
+
+	mode1, count1, ok1 := SliceMode([]string{"b", "a", "c", "a", "b", "a"})
+	mode2, count2, ok2 := SliceMode([]int{3, 1, 1, 3, 2})
+	mode3, count3, ok3 := SliceMode([]int{})
+
+	require.True(t, ok1)
+	require.Equal(t, "a", mode1)
+	require.Equal(t, 3, count1)
+	require.True(t, ok2)
+	require.Equal(t, 3, mode2)
+	require.Equal(t, 2, count2)
+	require.False(t, ok3)
+	require.Equal(t, 0, mode3)
+	require.Equal(t, 0, count3)
+}
+
 func TestSliceValuesCountBy(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +967,58 @@ func TestSliceValuesCountBy(t *testing.T) {
 	require.Equal(t, map[int]int{}, res2)
 }
 
+func TestSliceHistogram(t *testing.T) {
+	t.Parallel()
+
+	status := func(code int) string {
+		return strconv.Itoa(code/100) + "xx"
+	}
+
+	res1 := SliceHistogram([]int{200, 404, 500, 201, 503, 204, 302, 404}, status)
+	res2 := SliceHistogram([]int{}, status)
+
+	require.Equal(t, []types.Entry[string, int]{
+		{Key: "2xx", Value: 3},
+		{Key: "4xx", Value: 2},
+		{Key: "5xx", Value: 2},
+		{Key: "3xx", Value: 1},
+	}, res1)
+	require.Equal(t, []types.Entry[string, int]{}, res2)
+}
+
+func TestSliceReverseIndex(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceReverseIndex([]string{"a", "b", "a", "c", "b", "a"})
+	res2 := SliceReverseIndex([]int{})
+
+	require.Equal(t, map[string][]int{"a": {0, 2, 5}, "b": {1, 4}, "c": {3}}, res1)
+	require.Equal(t, map[int][]int{}, res2)
+}
+
+func TestSliceRunLengthEncodeAndDecode(t *testing.T) {
+	t.Parallel()
+
+	collection := []string{"a", "a", "b", "a", "a", "a"}
+	res1 := SliceRunLengthEncode(collection)
+	res2 := SliceRunLengthEncode([]string{})
+
+	require.Equal(t, []types.Entry[string, int]{
+		{Key: "a", Value: 2},
+		{Key: "b", Value: 1},
+		{Key: "a", Value: 3},
+	}, res1)
+	require.Equal(t, []types.Entry[string, int]{}, res2)
+	require.Equal(t, []types.Entry[string, int]{
+		{Key: "a", Value: 2},
+		{Key: "b", Value: 1},
+	}, SliceRunLengthEncode([]string{"a", "a", "b"}))
+
+	require.Equal(t, collection, SliceRunLengthDecode(res1))
+	require.Equal(t, []string{}, SliceRunLengthDecode(res2))
+	require.Equal(t, []int{1, 3}, SliceRunLengthDecode([]types.Entry[int, int]{{Key: 1, Value: 1}, {Key: 2, Value: 0}, {Key: 3, Value: 1}}))
+}
+
 func TestSliceSubset(t *testing.T) {
 	t.Parallel()
 
@@ -601,3 +1084,454 @@ func TestSliceReplaceAll(t *testing.T) {
 	require.Equal(t, []int{1, 2, 2, 5, 5, 5, 4, 4, 4, 4}, res1)
 	require.Equal(t, arr, res2)
 }
+
+func TestSliceReplaceFunc(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		Name   string
+		Active bool
+	}
+	inactive := func(i item) bool {
+		return !i.Active
+	}
+	activate := func(i item) item {
+		i.Active = true
+		return i
+	}
+	collection := []item{{"a", false}, {"b", true}, {"c", false}, {"d", false}}
+
+	res1 := SliceReplaceFunc(collection, inactive, activate, 2)
+	res2 := SliceReplaceFunc(collection, inactive, activate, -1)
+	res3 := SliceReplaceFunc(collection, inactive, activate, 0)
+
+	require.Equal(t, []item{{"a", true}, {"b", true}, {"c", true}, {"d", false}}, res1)
+	require.Equal(t, []item{{"a", true}, {"b", true}, {"c", true}, {"d", true}}, res2)
+	require.Equal(t, collection, res3)
+	require.False(t, collection[0].Active)
+}
+
+func TestSliceDistinctWindow(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceDistinctWindow([]int{1, 2, 1, 3, 4, 1}, 2)
+	res2 := SliceDistinctWindow([]int{1, 1, 2, 2, 1}, 0)
+	res3 := SliceDistinctWindow([]int{1, 1, 2, 2, 1}, 10)
+	res4 := SliceDistinctWindow([]int{}, 2)
+
+	require.Equal(t, []int{1, 2, 3, 4, 1}, res1)
+	require.Equal(t, []int{1, 1, 2, 2, 1}, res2)
+	require.Equal(t, []int{1, 2}, res3)
+	require.Equal(t, []int{}, res4)
+}
+
+func TestSliceDistinctByLast(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		Key     string
+		Version int
+	}
+	key := func(item record) string {
+		return item.Key
+	}
+
+	res1 := SliceDistinctByLast([]record{{"a", 1}, {"b", 1}, {"a", 2}, {"c", 1}, {"b", 2}}, key)
+	res2 := SliceDistinctByLast([]record{}, key)
+
+	require.Equal(t, []record{{"a", 2}, {"c", 1}, {"b", 2}}, res1)
+	require.Equal(t, []record{}, res2)
+}
+
+func TestReservoirSample(t *testing.T) {
+	t.Parallel()
+
+	stream := func(n int) <-chan int {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for i := 0; i < n; i++ {
+				ch <- i
+			}
+		}()
+		return ch
+	}
+
+	res1 := ReservoirSample(stream(3), 5)
+	res2 := ReservoirSample(stream(100), 5)
+	res3 := ReservoirSampleWithRand(stream(100), 5, rand.New(rand.NewSource(1)))
+	res4 := ReservoirSampleWithRand(stream(100), 5, rand.New(rand.NewSource(1)))
+	res5 := ReservoirSample(stream(10), 0)
+
+	require.Equal(t, []int{0, 1, 2}, res1)
+	require.Len(t, res2, 5)
+	require.True(t, SliceAllUnique(res2))
+	require.Equal(t, res3, res4)
+	require.Empty(t, res5)
+
+	// Every element should be picked roughly k/n of the time.
+	counts := make(map[int]int)
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 2000; i++ {
+		for _, v := range ReservoirSampleWithRand(stream(10), 5, r) {
+			counts[v]++
+		}
+	}
+	for i := 0; i < 10; i++ {
+		require.InDelta(t, 1000, counts[i], 200)
+	}
+}
+
+func TestSliceFirstNonZero(t *testing.T) {
+	t.Parallel()
+
+	res1, ok1 := SliceFirstNonZero([]string{"", "a", "b"})
+	res2, ok2 := SliceFirstNonZero([]string{"", ""})
+	res3, ok3 := SliceFirstNonZero([]int{})
+
+	require.True(t, ok1)
+	require.Equal(t, "a", res1)
+	require.False(t, ok2)
+	require.Equal(t, "", res2)
+	require.False(t, ok3)
+	require.Equal(t, 0, res3)
+}
+
+func TestSliceLastNonZero(t *testing.T) {
+	t.Parallel()
+
+	res1, ok1 := SliceLastNonZero([]int{0, 1, 2, 0})
+	res2, ok2 := SliceLastNonZero([]int{0, 0})
+
+	require.True(t, ok1)
+	require.Equal(t, 2, res1)
+	require.False(t, ok2)
+	require.Equal(t, 0, res2)
+}
+
+func TestSliceContainsSubslice(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceContainsSubslice([]int{1, 2, 3, 4, 5}, []int{2, 3, 4})
+	res2 := SliceContainsSubslice([]int{1, 2, 3, 4, 5}, []int{1, 3, 5})
+	res3 := SliceContainsSubslice([]int{1, 2, 3}, []int{})
+	res4 := SliceContainsSubslice([]int{1, 2}, []int{1, 2, 3})
+	res5 := SliceContainsSubslice([]int{1, 1, 2}, []int{1, 2})
+
+	require.True(t, res1)
+	require.False(t, res2)
+	require.True(t, res3)
+	require.False(t, res4)
+	require.True(t, res5)
+}
+
+func TestSliceStartsWith(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceStartsWith([]int{1, 2, 3}, []int{1, 2})
+	res2 := SliceStartsWith([]int{1, 2, 3}, []int{2, 3})
+	res3 := SliceStartsWith([]int{1, 2, 3}, []int{})
+	res4 := SliceStartsWith([]int{1, 2}, []int{1, 2, 3})
+
+	require.True(t, res1)
+	require.False(t, res2)
+	require.True(t, res3)
+	require.False(t, res4)
+}
+
+func TestSliceEndsWith(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceEndsWith([]int{1, 2, 3}, []int{2, 3})
+	res2 := SliceEndsWith([]int{1, 2, 3}, []int{1, 2})
+	res3 := SliceEndsWith([]int{1, 2, 3}, nil)
+	res4 := SliceEndsWith([]int{2, 3}, []int{1, 2, 3})
+
+	require.True(t, res1)
+	require.False(t, res2)
+	require.True(t, res3)
+	require.False(t, res4)
+}
+
+func TestSliceValuesSeq(t *testing.T) {
+	t.Parallel()
+
+	collection := []int{1, 2, 3, 4}
+	res1 := make([]int, 0)
+	for item := range SliceValuesSeq(collection) {
+		if item == 3 {
+			break
+		}
+		res1 = append(res1, item)
+	}
+	res2 := CollectSeq(SliceValuesSeq(collection))
+	res3 := CollectSeq(SliceValuesSeq([]int{}))
+
+	require.Equal(t, []int{1, 2}, res1)
+	require.Equal(t, collection, res2)
+	require.Equal(t, []int{}, res3)
+}
+
+func TestSliceSeq2(t *testing.T) {
+	t.Parallel()
+
+	indexes := make([]int, 0)
+	items := make([]string, 0)
+	for i, item := range SliceSeq2([]string{"a", "b", "c"}) {
+		indexes = append(indexes, i)
+		items = append(items, item)
+	}
+
+	require.Equal(t, []int{0, 1, 2}, indexes)
+	require.Equal(t, []string{"a", "b", "c"}, items)
+}
+
+func TestSliceGroupReduce(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		Category string
+		Price    int
+	}
+	items := []item{{"a", 1}, {"b", 2}, {"a", 3}, {"c", 4}, {"b", 5}}
+
+	res1 := SliceGroupReduce(items, func(i item) string {
+		return i.Category
+	}, func(agg int, i item) int {
+		return agg + i.Price
+	}, 0)
+	res2 := SliceGroupReduce([]item{}, func(i item) string {
+		return i.Category
+	}, func(agg int, i item) int {
+		return agg + i.Price
+	}, 0)
+
+	require.Equal(t, map[string]int{"a": 4, "b": 7, "c": 4}, res1)
+	require.Equal(t, map[string]int{}, res2)
+}
+
+func TestSliceWeightedSample(t *testing.T) {
+	t.Parallel()
+
+	weights := map[string]float64{"heavy": 98, "light": 1, "lighter": 1, "never": 0}
+	collection := []string{"light", "heavy", "never", "lighter"}
+	weight := func(item string) float64 {
+		return weights[item]
+	}
+
+	counts := make(map[string]int)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		item, ok := SliceWeightedSampleWithRand(collection, weight, r)
+		require.True(t, ok)
+		counts[item]++
+	}
+	require.Greater(t, counts["heavy"], 900)
+	require.Zero(t, counts["never"])
+
+	_, ok := SliceWeightedSample([]string{"never"}, weight)
+	require.False(t, ok)
+	_, ok = SliceWeightedSample([]string{}, weight)
+	require.False(t, ok)
+}
+
+func TestSliceSampleStable(t *testing.T) {
+	t.Parallel()
+
+	collection := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	res1 := SliceSampleStable(collection, 4, 42)
+	res2 := SliceSampleStable(collection, 4, 42)
+	require.Equal(t, res1, res2)
+	require.Len(t, res1, 4)
+	require.True(t, slices.IsSorted(res1))
+	require.True(t, SliceAllUnique(res1))
+	require.True(t, SliceContainsAll(collection, res1))
+
+	require.Equal(t, collection, SliceSampleStable(collection, 20, 42))
+	require.Equal(t, []int{}, SliceSampleStable(collection, 0, 42))
+}
+
+func TestSliceResample(t *testing.T) {
+	t.Parallel()
+
+	series := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	lerp := func(a, b float64, t float64) float64 {
+		return a + (b-a)*t
+	}
+
+	// Downsampling keeps the first and last points
+	require.Equal(t, []float64{0, 2, 5, 7, 9}, SliceResample(series, 5, nil))
+	down := SliceResample(series, 5, lerp)
+	require.Len(t, down, 5)
+	for i, expected := range []float64{0, 2.25, 4.5, 6.75, 9} {
+		require.InDelta(t, expected, down[i], 1e-9)
+	}
+
+	// Upsampling
+	up := SliceResample(series, 20, lerp)
+	require.Len(t, up, 20)
+	for i := range up {
+		require.InDelta(t, float64(i)*9/19, up[i], 1e-9)
+	}
+	nearest := SliceResample(series, 20, nil)
+	require.Len(t, nearest, 20)
+	require.Equal(t, []float64{0, 0, 1, 1, 2}, nearest[:5])
+	require.Equal(t, float64(9), nearest[19])
+
+	require.Equal(t, []float64{0}, SliceResample(series, 1, lerp))
+	require.Equal(t, []float64{}, SliceResample(series, 0, nil))
+	require.Equal(t, []float64{}, SliceResample([]float64{}, 3, nil))
+}
+
+func TestSliceJoinToString(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceJoinToString([]int{1, 2, 3}, ", ", func(item int) string {
+		return "#" + strconv.Itoa(item)
+	})
+	res2 := SliceJoinToString([]int{1, 2, 3}, "-", nil)
+	res3 := SliceJoinToString([]int{}, ",", nil)
+
+	require.Equal(t, "#1, #2, #3", res1)
+	require.Equal(t, "1-2-3", res2)
+	require.Equal(t, "", res3)
+}
+
+func TestSliceRemoveFunc(t *testing.T) {
+	t.Parallel()
+
+	collection := []int{1, 2, 3, 4, 5, 6}
+	res1, count1 := SliceRemoveFunc(collection, func(item int) bool {
+		return item%2 == 0
+	})
+	res2, count2 := SliceRemoveFunc([]int{1, 3}, func(item int) bool {
+		return item%2 == 0
+	})
+	res3, count3 := SliceRemoveFunc([]int{}, func(item int) bool {
+		return true
+	})
+
+	require.Equal(t, []int{1, 3, 5}, res1)
+	require.Equal(t, 3, count1)
+	require.Equal(t, []int{1, 3, 5, 0, 0, 0}, collection)
+	require.Equal(t, []int{1, 3}, res2)
+	require.Equal(t, 0, count2)
+	require.Equal(t, []int{}, res3)
+	require.Equal(t, 0, count3)
+}
+
+func TestSliceAllUnique(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceAllUnique([]int{1, 2, 3})
+	res2 := SliceAllUnique([]int{1, 2, 1})
+	res3 := SliceAllUnique([]int{})
+
+	require.True(t, res1)
+	require.False(t, res2)
+	require.True(t, res3)
+}
+
+func TestSliceDuplicates(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceDuplicates([]int{3, 1, 2, 1, 3, 3, 4, 2, 1})
+	res2 := SliceDuplicates([]string{"a", "b", "c"})
+	res3 := SliceDuplicates([]int{})
+
+	require.Equal(t, []int{1, 3, 2}, res1)
+	require.Empty(t, res2)
+	require.Empty(t, res3)
+}
+
+func TestSliceSortedInsert(t *testing.T) {
+	t.Parallel()
+
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	res1 := SliceSortedInsert([]int{1, 3, 5, 7}, 4, less)
+	res2 := SliceSortedInsert([]int{1, 3, 5, 7}, 0, less)
+	res3 := SliceSortedInsert([]int{1, 3, 5, 7}, 9, less)
+	res4 := SliceSortedInsert([]int{}, 1, less)
+
+	require.Equal(t, []int{1, 3, 4, 5, 7}, res1)
+	require.Equal(t, []int{0, 1, 3, 5, 7}, res2)
+	require.Equal(t, []int{1, 3, 5, 7, 9}, res3)
+	require.Equal(t, []int{1}, res4)
+
+	type pair struct {
+		K int
+		V string
+	}
+	res5 := SliceSortedInsert([]pair{{1, "a"}, {2, "b"}, {3, "c"}}, pair{2, "x"}, func(a, b pair) bool {
+		return a.K < b.K
+	})
+	require.Equal(t, []pair{{1, "a"}, {2, "b"}, {2, "x"}, {3, "c"}}, res5)
+}
+
+func TestSliceEnsureCap(t *testing.T) {
+	t.Parallel()
+
+	collection := make([]int, 3, 4)
+	collection[0], collection[1], collection[2] = 1, 2, 3
+
+	res1 := SliceEnsureCap(collection, 10)
+	require.Equal(t, []int{1, 2, 3}, res1)
+	require.GreaterOrEqual(t, cap(res1), 13)
+
+	res2 := SliceEnsureCap(collection, 1)
+	require.Equal(t, []int{1, 2, 3}, res2)
+	require.Equal(t, 4, cap(res2))
+	require.Same(t, &collection[0], &res2[0])
+
+	res3 := SliceEnsureCap([]int(nil), 5)
+	require.Empty(t, res3)
+	require.GreaterOrEqual(t, cap(res3), 5)
+
+	require.Equal(t, collection, SliceEnsureCap(collection, -1))
+}
+
+func TestSliceSplitAt(t *testing.T) {
+	t.Parallel()
+
+	collection := []int{1, 2, 3, 4}
+	left1, right1 := SliceSplitAt(collection, 1)
+	left2, right2 := SliceSplitAt(collection, 0)
+	left3, right3 := SliceSplitAt(collection, 4)
+	left4, right4 := SliceSplitAt(collection, 10)
+	left5, right5 := SliceSplitAt(collection, -1)
+
+	require.Equal(t, []int{1}, left1)
+	require.Equal(t, []int{2, 3, 4}, right1)
+	require.Equal(t, []int{}, left2)
+	require.Equal(t, collection, right2)
+	require.Equal(t, collection, left3)
+	require.Equal(t, []int{}, right3)
+	require.Equal(t, collection, left4)
+	require.Equal(t, []int{}, right4)
+	require.Equal(t, []int{}, left5)
+	require.Equal(t, collection, right5)
+
+	// The halves are independent copies
+	left1[0] = 9
+	right1[0] = 9
+	require.Equal(t, []int{1, 2, 3, 4}, collection)
+}
+
+func TestSliceIndicesWhere(t *testing.T) {
+	t.Parallel()
+
+	res1 := SliceIndicesWhere([]int{1, 2, 3, 4, 6}, func(item int) bool {
+		return item%2 == 0
+	})
+	res2 := SliceIndicesWhere([]int{1, 3}, func(item int) bool {
+		return item%2 == 0
+	})
+
+	require.Equal(t, []int{1, 3, 4}, res1)
+	require.NotNil(t, res2)
+	require.Equal(t, []int{}, res2)
+}
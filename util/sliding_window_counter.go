@@ -0,0 +1,69 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// SlidingWindowCounter counts increments within a trailing time window.
+// The window is split into a ring of buckets, so increments age out one bucket at a time,
+// and the precision of Count is the window divided by the number of buckets.
+// It is safe for concurrent use.
+type SlidingWindowCounter struct {
+	mu         sync.Mutex
+	bucketSize int64
+	counts     []int64
+	epochs     []int64
+
+	now func() time.Time
+}
+
+// NewSlidingWindowCounter creates a new SlidingWindowCounter instance.
+//
+//	params:
+//		- window: the length of the trailing window. Values less than buckets nanoseconds are raised to it.
+//		- buckets: the number of buckets the window is split into. Values less than 1 are treated as 1.
+func NewSlidingWindowCounter(window time.Duration, buckets int) *SlidingWindowCounter {
+	if buckets < 1 {
+		buckets = 1
+	}
+	bucketSize := int64(window) / int64(buckets)
+	if bucketSize < 1 {
+		bucketSize = 1
+	}
+	return &SlidingWindowCounter{
+		bucketSize: bucketSize,
+		counts:     make([]int64, buckets),
+		epochs:     make([]int64, buckets),
+		now:        time.Now,
+	}
+}
+
+// Inc records one increment at the current time.
+func (c *SlidingWindowCounter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	epoch := c.now().UnixNano() / c.bucketSize
+	i := epoch % int64(len(c.counts))
+	if c.epochs[i] != epoch {
+		// The bucket holds increments from a previous round of the ring, which are out of the window.
+		c.epochs[i] = epoch
+		c.counts[i] = 0
+	}
+	c.counts[i]++
+}
+
+// Count returns the number of increments within the trailing window.
+func (c *SlidingWindowCounter) Count() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	epoch := c.now().UnixNano() / c.bucketSize
+	oldest := epoch - int64(len(c.counts))
+	var total int64
+	for i, e := range c.epochs {
+		if e > oldest && e <= epoch {
+			total += c.counts[i]
+		}
+	}
+	return total
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlidingWindowCounter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	c := NewSlidingWindowCounter(time.Second, 10)
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		c.Inc()
+	}
+	now = now.Add(500 * time.Millisecond)
+	c.Inc()
+	c.Inc()
+	require.Equal(t, int64(5), c.Count())
+
+	// The first 3 increments age out after the window elapses.
+	now = now.Add(600 * time.Millisecond)
+	require.Equal(t, int64(2), c.Count())
+
+	now = now.Add(time.Second)
+	require.Equal(t, int64(0), c.Count())
+
+	// Buckets reused by a later round of the ring start from zero.
+	c.Inc()
+	require.Equal(t, int64(1), c.Count())
+}
+
+func TestSlidingWindowCounterConcurrent(t *testing.T) {
+	t.Parallel()
+
+	c := NewSlidingWindowCounter(time.Minute, 60)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				c.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int64(800), c.Count())
+}
@@ -2,6 +2,8 @@ package util
 
 import (
 	"errors"
+	"math"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
@@ -48,3 +50,61 @@ func ParseToBytesSize(sizeStr string, base int64) (int64, error) {
 	}
 	return int64(value), nil
 }
+
+var (
+	// ErrBytesSizeOverflow is returned by ParseToBytesSizeStrict when the result exceeds math.MaxInt64.
+	ErrBytesSizeOverflow = errors.New("bytes size overflows int64")
+
+	strictBytesSizeRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)([bBkKmMgGtTpPeE]?)$`)
+)
+
+// ParseToBytesSizeStrict is like ParseToBytesSize, but it uses exact arithmetic instead of float64,
+// supports the additional units P and E, and returns ErrBytesSizeOverflow if the result exceeds math.MaxInt64.
+// Fractional byte results are truncated.
+//
+// params:
+//   - sizeStr: Size string, e.g., "1K", "500B", "2M", "16E", etc.
+//   - base: Base used to calculate the multiplication factor for units, e.g., 1024.
+//
+// Returns the converted byte size and possible error.
+func ParseToBytesSizeStrict(sizeStr string, base int64) (int64, error) {
+	if base <= 0 {
+		return 0, errors.New("invalid base")
+	}
+	match := strictBytesSizeRegex.FindStringSubmatch(sizeStr)
+	if match == nil {
+		return 0, errors.New("invalid size string")
+	}
+
+	value, ok := new(big.Rat).SetString(match[1])
+	if !ok {
+		return 0, errors.New("invalid size string")
+	}
+	// An empty unit means bytes, same as "b"
+	exponent := int64(strings.Index("bkmgtpe", strings.ToLower(match[2])))
+	factor := new(big.Int).Exp(big.NewInt(base), big.NewInt(exponent), nil)
+	value.Mul(value, new(big.Rat).SetInt(factor))
+
+	// Truncate the fractional part
+	result := new(big.Int).Quo(value.Num(), value.Denom())
+	if result.Cmp(big.NewInt(math.MaxInt64)) > 0 {
+		return 0, ErrBytesSizeOverflow
+	}
+	return result.Int64(), nil
+}
+
+// ParseBool converts a string to a bool like strconv.ParseBool,
+// but it also accepts the tokens commonly used in configuration files.
+// The string is matched case-insensitively after trimming spaces.
+//
+// Accepted truthy values: 1, t, true, y, yes, on, enabled.
+// Accepted falsy values: 0, f, false, n, no, off, disabled.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "t", "true", "y", "yes", "on", "enabled":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off", "disabled":
+		return false, nil
+	}
+	return false, errors.New("invalid bool string")
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -43,3 +44,81 @@ func TestParseToBytesSize(t *testing.T) {
 		t.Errorf("Should return an error")
 	}
 }
+
+func TestParseToBytesSizeStrict(t *testing.T) {
+	tests := []struct {
+		input    string
+		base     int64
+		expected int64
+	}{
+		{"100", 1024, 100},
+		{"1024B", 1024, 1024},
+		{"1K", 1024, 1024},
+		{"2.5K", 1024, 2560},
+		{"1T", 1024, 1099511627776},
+		{"1P", 1024, 1125899906842624},
+		{"7E", 1024, 8070450532247928832},
+		{"1.5b", 1000, 1},
+		{"1E", 1000, 1000000000000000000},
+	}
+
+	for _, test := range tests {
+		result, err := ParseToBytesSizeStrict(test.input, test.base)
+		if err != nil {
+			t.Errorf("Error parsing size string '%s': %s", test.input, err)
+		}
+
+		if result != test.expected {
+			t.Errorf("Size mismatch for input '%s'. Expected: %d, Got: %d", test.input, test.expected, result)
+		}
+	}
+
+	_, err := ParseToBytesSizeStrict("16E", 1024)
+	if !errors.Is(err, ErrBytesSizeOverflow) {
+		t.Errorf("Should return an overflow error, got %v", err)
+	}
+
+	_, err = ParseToBytesSizeStrict("2.5.5", 1000)
+	if err == nil {
+		t.Errorf("Should return an error")
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1", true},
+		{"t", true},
+		{"TRUE", true},
+		{"y", true},
+		{"Yes", true},
+		{"on", true},
+		{" Enabled ", true},
+		{"0", false},
+		{"F", false},
+		{"false", false},
+		{"N", false},
+		{"no", false},
+		{"OFF", false},
+		{"disabled", false},
+	}
+
+	for _, test := range tests {
+		result, err := ParseBool(test.input)
+		if err != nil {
+			t.Errorf("Error parsing bool string '%s': %s", test.input, err)
+		}
+
+		if result != test.expected {
+			t.Errorf("Bool mismatch for input '%s'. Expected: %t, Got: %t", test.input, test.expected, result)
+		}
+	}
+
+	for _, input := range []string{"", "maybe", "2", "yess"} {
+		if _, err := ParseBool(input); err == nil {
+			t.Errorf("Expected an error for input '%s'", input)
+		}
+	}
+}
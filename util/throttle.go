@@ -0,0 +1,41 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// ThrottledFunc returns a function that invokes fn at most once per interval d.
+// The first call invokes fn immediately, and calls made within d after an invocation are dropped.
+// The returned function is safe for concurrent use.
+func ThrottledFunc(d time.Duration, fn func(args ...any)) func(args ...any) {
+	return ThrottledFuncWithDropped(d, func(dropped int, args ...any) {
+		fn(args...)
+	})
+}
+
+// ThrottledFuncWithDropped is like ThrottledFunc, but fn also receives the number of calls
+// dropped since its previous invocation, e.g. to log "(suppressed N similar messages)".
+func ThrottledFuncWithDropped(d time.Duration, fn func(dropped int, args ...any)) func(args ...any) {
+	var (
+		mu      sync.Mutex
+		last    time.Time
+		called  bool
+		dropped int
+	)
+	return func(args ...any) {
+		mu.Lock()
+		now := time.Now()
+		if called && now.Sub(last) < d {
+			dropped++
+			mu.Unlock()
+			return
+		}
+		called, last = true, now
+		n := dropped
+		dropped = 0
+		mu.Unlock()
+
+		fn(n, args...)
+	}
+}
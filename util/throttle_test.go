@@ -0,0 +1,53 @@
+package util
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottledFunc(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls [][]any
+	)
+	f := ThrottledFunc(time.Hour, func(args ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, args)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f("disk full", 1)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, [][]any{{"disk full", 1}}, calls)
+}
+
+func TestThrottledFuncWithDropped(t *testing.T) {
+	t.Parallel()
+
+	var dropped []int
+	f := ThrottledFuncWithDropped(30*time.Millisecond, func(n int, args ...any) {
+		dropped = append(dropped, n)
+	})
+
+	for i := 0; i < 5; i++ {
+		f()
+	}
+	time.Sleep(40 * time.Millisecond)
+	f()
+	f()
+
+	require.Equal(t, []int{0, 4}, dropped)
+}
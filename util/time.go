@@ -0,0 +1,52 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTimeout is returned by WithTimeout when the function does not return in time.
+var ErrTimeout = errors.New("timeout")
+
+// SleepCtx pauses the current goroutine for the duration d, or until ctx is done.
+// It returns nil after d, or ctx.Err() if ctx is done first.
+func SleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WithTimeout runs fn in a new goroutine and returns its result, or the zero value and ErrTimeout if d elapses first.
+// A goroutine can not be killed, so on timeout fn keeps running in the background and its result is discarded.
+// Callers should make fn cancelable, e.g. by a context with the same deadline, to avoid leaking work.
+func WithTimeout[T any](d time.Duration, fn func() (T, error)) (T, error) {
+	type result struct {
+		v   T
+		err error
+	}
+	// Buffered, so that the goroutine can exit after a timeout
+	resC := make(chan result, 1)
+	go func() {
+		v, err := fn()
+		resC <- result{v: v, err: err}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case res := <-resC:
+		return res.v, res.err
+	case <-timer.C:
+		var zero T
+		return zero, ErrTimeout
+	}
+}
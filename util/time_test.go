@@ -0,0 +1,55 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSleepCtx(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	require.NoError(t, SleepCtx(context.Background(), 20*time.Millisecond))
+	require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	start = time.Now()
+	err := SleepCtx(ctx, time.Minute)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(start), time.Second)
+
+	require.ErrorIs(t, SleepCtx(ctx, 0), context.Canceled)
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	v, err := WithTimeout(time.Second, func() (int, error) {
+		return 42, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+
+	errFailed := errors.New("failed")
+	_, err = WithTimeout(time.Second, func() (int, error) {
+		return 0, errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+
+	start := time.Now()
+	v, err = WithTimeout(20*time.Millisecond, func() (int, error) {
+		time.Sleep(time.Second)
+		return 42, nil
+	})
+	require.ErrorIs(t, err, ErrTimeout)
+	require.Equal(t, 0, v)
+	require.Less(t, time.Since(start), time.Second)
+}
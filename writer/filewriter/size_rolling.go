@@ -84,12 +84,27 @@ func (w *SizeRollingFileWriter) Write(bz []byte) (n int, err error) {
 	if err != nil {
 		return n, err
 	}
-	if w.fileSizeLimit > 0 {
-		w.currentSize += int64(n)
-	}
+	w.currentSize += int64(n)
 	return
 }
 
+// CurrentFile returns the path of the file being written to, or an empty string if the writer is closed.
+func (w *SizeRollingFileWriter) CurrentFile() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return ""
+	}
+	return w.file.Name()
+}
+
+// CurrentSize returns the size in bytes of the file being written to.
+func (w *SizeRollingFileWriter) CurrentSize() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.currentSize
+}
+
 // tryRotate checks if the current file size exceeds the limit and performs log rotation if necessary.
 func (w *SizeRollingFileWriter) tryRotate(bytesLength int64) error {
 	if w.fileSizeLimit <= 0 {
@@ -81,3 +81,47 @@ func TestSizeRollingFileWriter_Write(t *testing.T) {
 		t.Fatalf("Expected %d backup files, got %d", maxBackups, len(backupFiles))
 	}
 }
+
+func TestSizeRollingFileWriter_CurrentFileAndSize(t *testing.T) {
+	// Create a temporary directory for testing
+	tempDir, err := os.MkdirTemp("", "filewriter_test")
+	if err != nil {
+		t.Fatal("Failed to create temporary directory:", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writer, err := NewSizeRollingFileWriter(tempDir, "test.log", 3, 100)
+	if err != nil {
+		t.Fatal("Failed to create SizeRollingFileWriter:", err)
+	}
+
+	filePath := filepath.Join(tempDir, "test.log")
+	if writer.CurrentFile() != filePath {
+		t.Errorf("Unexpected current file. Expected: %s, Got: %s", filePath, writer.CurrentFile())
+	}
+
+	data := []byte("Hello, World!")
+	for i := 1; i <= 3; i++ {
+		if _, err = writer.Write(data); err != nil {
+			t.Fatal("Failed to write data:", err)
+		}
+		if size := writer.CurrentSize(); size != int64(i*len(data)) {
+			t.Errorf("Unexpected current size. Expected: %d, Got: %d", i*len(data), size)
+		}
+	}
+
+	// Rotation starts a new empty file
+	if _, err = writer.Write(bytes.Repeat([]byte("a"), 90)); err != nil {
+		t.Fatal("Failed to write data:", err)
+	}
+	if size := writer.CurrentSize(); size != 90 {
+		t.Errorf("Unexpected current size after rotation. Expected: 90, Got: %d", size)
+	}
+
+	if err = writer.Close(); err != nil {
+		t.Fatal("Failed to close writer:", err)
+	}
+	if writer.CurrentFile() != "" {
+		t.Errorf("Unexpected current file after close: %s", writer.CurrentFile())
+	}
+}
@@ -0,0 +1,44 @@
+package filewriter
+
+import (
+	"errors"
+	"io"
+)
+
+// teeWriter is an io.Writer that fans each write out to all of its writers
+type teeWriter struct {
+	writers []io.Writer
+}
+
+// NewTeeWriter creates an io.Writer that writes to all the given writers, e.g. a rolling file writer and os.Stdout.
+// Unlike io.MultiWriter, a failing writer does not stop the write to the others.
+// The errors of all failing writers are joined and returned together,
+// with the least number of bytes written by any writer.
+func NewTeeWriter(writers ...io.Writer) io.Writer {
+	ws := make([]io.Writer, 0, len(writers))
+	for _, w := range writers {
+		if w != nil {
+			ws = append(ws, w)
+		}
+	}
+	return &teeWriter{writers: ws}
+}
+
+// Write writes p to all writers
+func (t *teeWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	var errs []error
+	for _, w := range t.writers {
+		written, err := w.Write(p)
+		if err == nil && written < len(p) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if written < n {
+			n = written
+		}
+	}
+	return n, errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package filewriter
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestTeeWriter_Write(t *testing.T) {
+	// Create a temporary directory for testing
+	tempDir, err := os.MkdirTemp("", "filewriter_test")
+	if err != nil {
+		t.Fatal("Failed to create temporary directory:", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writer, err := NewSizeRollingFileWriter(tempDir, "test.log", 3, 1024)
+	if err != nil {
+		t.Fatal("Failed to create SizeRollingFileWriter:", err)
+	}
+	defer writer.Close()
+
+	// Write data to both the file and the buffer
+	var buf bytes.Buffer
+	tee := NewTeeWriter(writer, &buf)
+	data := []byte("Hello, World!")
+	n, err := tee.Write(data)
+	if err != nil {
+		t.Fatal("Failed to write data:", err)
+	}
+	if n != len(data) {
+		t.Errorf("Unexpected written bytes. Expected: %d, Got: %d", len(data), n)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Unexpected buffer content. Expected: %s, Got: %s", data, buf.Bytes())
+	}
+	fileContent, err := os.ReadFile(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatal("Failed to read file:", err)
+	}
+	if !bytes.Equal(fileContent, data) {
+		t.Errorf("Unexpected file content. Expected: %s, Got: %s", data, fileContent)
+	}
+}
+
+func TestTeeWriter_WriteError(t *testing.T) {
+	errBroken := errors.New("broken pipe")
+	var buf bytes.Buffer
+	tee := NewTeeWriter(&failingWriter{err: errBroken}, &buf)
+
+	data := []byte("Hello, World!")
+	n, err := tee.Write(data)
+	if !errors.Is(err, errBroken) {
+		t.Errorf("Unexpected error. Expected: %v, Got: %v", errBroken, err)
+	}
+	if n != 0 {
+		t.Errorf("Unexpected written bytes. Expected: 0, Got: %d", n)
+	}
+	// The healthy writer still receives the data
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Unexpected buffer content. Expected: %s, Got: %s", data, buf.Bytes())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -32,6 +33,17 @@ var (
 	TimeFormatSecond = "20060102_15_04_05"
 )
 
+const (
+	// FileNameTemplatePrefix is the placeholder of the base file name without extension in a file name template
+	FileNameTemplatePrefix = "{prefix}"
+	// FileNameTemplateTime is the placeholder of the formatted rolling time in a file name template
+	FileNameTemplateTime = "{time}"
+	// FileNameTemplateExt is the placeholder of the base file extension, including the dot, in a file name template
+	FileNameTemplateExt = "{ext}"
+	// DefaultFileNameTemplate is the file name template used by NewTimeRollingFileWriter
+	DefaultFileNameTemplate = FileNameTemplatePrefix + "." + FileNameTemplateTime + FileNameTemplateExt
+)
+
 // TimeRollingFileWriter is a time-based rolling file writer
 type TimeRollingFileWriter struct {
 	mu              sync.Mutex
@@ -44,6 +56,11 @@ type TimeRollingFileWriter struct {
 	baseFileExt    string
 	maxBackups     int
 	rollPeriod     RollingPeriod
+
+	// fileNameBeforeTime and fileNameAfterTime are the rendered parts of the file name template
+	// around the time placeholder, using slash as separator
+	fileNameBeforeTime string
+	fileNameAfterTime  string
 }
 
 // NewTimeRollingFileWriter creates a new instance of TimeRollingFileWriter.
@@ -59,6 +76,28 @@ func NewTimeRollingFileWriter(
 	basePath, baseFileName string,
 	maxBackups int,
 	rollPeriod RollingPeriod,
+) (*TimeRollingFileWriter, error) {
+	return NewTimeRollingFileWriterWithTemplate(basePath, baseFileName, maxBackups, rollPeriod, DefaultFileNameTemplate)
+}
+
+// NewTimeRollingFileWriterWithTemplate creates a new instance of TimeRollingFileWriter
+// whose file names are laid out by the given template.
+//
+//	params:
+//		- basePath: defines the path to save the files.
+//		- baseFileName: defines the base name of the files.
+//		- maxBackups: defines the maximum number of file backups to keep.
+//			If there is no limit, set the value to a negative value.
+//		- rollPeriod: specify the time rolling period.
+//		- template: defines the layout of the file names relative to basePath, e.g. "{prefix}-{time}{ext}".
+//			It must contain the {time} placeholder exactly once, may contain the {prefix} and {ext} placeholders,
+//			and must not contain any other placeholder. Slashes put files into subdirectories,
+//			e.g. "{time}/{prefix}{ext}" with RollingPeriodDay creates a directory per day.
+func NewTimeRollingFileWriterWithTemplate(
+	basePath, baseFileName string,
+	maxBackups int,
+	rollPeriod RollingPeriod,
+	template string,
 ) (*TimeRollingFileWriter, error) {
 	if err := os.MkdirAll(basePath, os.ModePerm); err != nil {
 		return nil, err
@@ -71,6 +110,9 @@ func NewTimeRollingFileWriter(
 	w.maxBackups = maxBackups
 	w.baseFileExt = filepath.Ext(baseFileName)
 	w.baseFilePrefix = strings.TrimSuffix(baseFileName, w.baseFileExt)
+	if err := w.parseFileNameTemplate(template); err != nil {
+		return nil, err
+	}
 	switch rollPeriod {
 	case RollingPeriodYear, RollingPeriodMonth, RollingPeriodDay,
 		RollingPeriodHour, RollingPeriodMinute, RollingPeriodSecond:
@@ -106,6 +148,16 @@ func (w *TimeRollingFileWriter) Write(bz []byte) (n int, err error) {
 	return w.file.Write(bz)
 }
 
+// CurrentFile returns the path of the file being written to, or an empty string if the writer is closed
+func (w *TimeRollingFileWriter) CurrentFile() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return ""
+	}
+	return w.file.Name()
+}
+
 // tryRotate attempts to perform file rotation
 func (w *TimeRollingFileWriter) tryRotate() error {
 	var (
@@ -169,8 +221,11 @@ func (w *TimeRollingFileWriter) tryRotate() error {
 	}
 
 	// Open the new file
-	fileName := fmt.Sprintf("%s.%s%s", w.baseFilePrefix, now.Format(timeFormat), w.baseFileExt)
-	file, err := os.OpenFile(filepath.Join(w.basePath, fileName), os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
+	filePath := filepath.Join(w.basePath, filepath.FromSlash(w.fileNameBeforeTime+now.Format(timeFormat)+w.fileNameAfterTime))
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0666)
 	if err != nil {
 		return err
 	}
@@ -182,15 +237,16 @@ func (w *TimeRollingFileWriter) tryRotate() error {
 
 	if w.maxBackups >= 0 {
 		// Try to delete old files
-		go w.tryDeleteOldFiles()
+		go w.tryDeleteOldFiles(file.Name(), deleteCheckTime)
 	}
 
 	return nil
 }
 
-// tryDeleteOldFiles tries to delete old files based on the delete check time
-func (w *TimeRollingFileWriter) tryDeleteOldFiles() {
-	files, err := filepath.Glob(filepath.Join(w.basePath, w.baseFilePrefix+".*"+w.baseFileExt))
+// tryDeleteOldFiles tries to delete old files based on the delete check time.
+// The active file is never deleted, even if its index time is before the delete check time.
+func (w *TimeRollingFileWriter) tryDeleteOldFiles(activeFile string, deleteCheckTime time.Time) {
+	files, err := filepath.Glob(filepath.Join(w.basePath, filepath.FromSlash(w.fileNameBeforeTime+"*"+w.fileNameAfterTime)))
 	if err != nil {
 		fmt.Println("error while globbing files:", err)
 		return
@@ -200,28 +256,34 @@ func (w *TimeRollingFileWriter) tryDeleteOldFiles() {
 		return
 	}
 	sort.Slice(files, func(i, j int) bool {
-		indexTimeI, err := w.getFileIndexTime(files[i])
+		indexTimeI, err := w.getFileIndexTime(files[i], deleteCheckTime.Location())
 		if err != nil {
 			return false
 		}
-		indexTimeJ, err := w.getFileIndexTime(files[j])
+		indexTimeJ, err := w.getFileIndexTime(files[j], deleteCheckTime.Location())
 		if err != nil {
 			return false
 		}
 		return indexTimeI.After(indexTimeJ)
 	})
 	for _, file := range files {
-		fileTime, err := w.getFileIndexTime(file)
+		if file == activeFile {
+			continue
+		}
+		fileTime, err := w.getFileIndexTime(file, deleteCheckTime.Location())
 		if err != nil {
 			fmt.Println("error while getting file index time: " + err.Error())
 			fileCount--
 			continue
 		}
 		// Check if the file is older than the delete check time
-		if fileTime.Before(w.deleteCheckTime) {
+		if fileTime.Before(deleteCheckTime) {
 			err = os.Remove(file)
 			if err != nil {
 				fmt.Println("failed to remove old file:", err)
+			} else if dir := filepath.Dir(file); filepath.Clean(dir) != filepath.Clean(w.basePath) {
+				// Remove the subdirectory created by the template if it is empty now
+				_ = os.Remove(dir)
 			}
 			fileCount--
 		}
@@ -232,31 +294,58 @@ func (w *TimeRollingFileWriter) tryDeleteOldFiles() {
 }
 
 // getFileIndexTime extracts the index time from the given file name.
-// It parses the file name based on the rolling period and returns the corresponding time value.
-func (w *TimeRollingFileWriter) getFileIndexTime(file string) (time.Time, error) {
-	fileInfo, err := os.Stat(file)
+// It parses the file name based on the rolling period in the location loc and returns the corresponding time value.
+func (w *TimeRollingFileWriter) getFileIndexTime(file string, loc *time.Location) (time.Time, error) {
+	if _, err := os.Stat(file); err != nil {
+		return time.Time{}, err
+	}
+	fileName, err := filepath.Rel(w.basePath, file)
 	if err != nil {
 		return time.Time{}, err
 	}
-	fileName := fileInfo.Name()
-	fileName = strings.TrimSuffix(fileName, w.baseFileExt)
-	fileDate := strings.TrimPrefix(fileName, w.baseFilePrefix+".")
+	fileName = filepath.ToSlash(fileName)
+	if !strings.HasPrefix(fileName, w.fileNameBeforeTime) || !strings.HasSuffix(fileName, w.fileNameAfterTime) ||
+		len(fileName) < len(w.fileNameBeforeTime)+len(w.fileNameAfterTime) {
+		return time.Time{}, fmt.Errorf("file name %s does not match the file name template", fileName)
+	}
+	fileDate := fileName[len(w.fileNameBeforeTime) : len(fileName)-len(w.fileNameAfterTime)]
 	var fileTime time.Time
 	switch w.rollPeriod {
 	case RollingPeriodYear:
-		fileTime, err = time.ParseInLocation(TimeFormatYear, fileDate, w.deleteCheckTime.Location())
+		fileTime, err = time.ParseInLocation(TimeFormatYear, fileDate, loc)
 	case RollingPeriodMonth:
-		fileTime, err = time.ParseInLocation(TimeFormatMonth, fileDate, w.deleteCheckTime.Location())
+		fileTime, err = time.ParseInLocation(TimeFormatMonth, fileDate, loc)
 	case RollingPeriodDay:
-		fileTime, err = time.ParseInLocation(TimeFormatDay, fileDate, w.deleteCheckTime.Location())
+		fileTime, err = time.ParseInLocation(TimeFormatDay, fileDate, loc)
 	case RollingPeriodHour:
-		fileTime, err = time.ParseInLocation(TimeFormatHour, fileDate, w.deleteCheckTime.Location())
+		fileTime, err = time.ParseInLocation(TimeFormatHour, fileDate, loc)
 	case RollingPeriodMinute:
-		fileTime, err = time.ParseInLocation(TimeFormatMinute, fileDate, w.deleteCheckTime.Location())
+		fileTime, err = time.ParseInLocation(TimeFormatMinute, fileDate, loc)
 	case RollingPeriodSecond:
-		fileTime, err = time.ParseInLocation(TimeFormatSecond, fileDate, w.deleteCheckTime.Location())
+		fileTime, err = time.ParseInLocation(TimeFormatSecond, fileDate, loc)
 	default:
 		panic("bug found! unexpected roll period value found")
 	}
 	return fileTime, err
 }
+
+// parseFileNameTemplate validates the file name template and renders its parts around the time placeholder.
+func (w *TimeRollingFileWriter) parseFileNameTemplate(template string) error {
+	template = filepath.ToSlash(template)
+	if strings.Count(template, FileNameTemplateTime) != 1 {
+		return errors.New("file name template must contain " + FileNameTemplateTime + " exactly once")
+	}
+	rest := strings.NewReplacer(FileNameTemplatePrefix, "", FileNameTemplateTime, "", FileNameTemplateExt, "").
+		Replace(template)
+	if strings.ContainsAny(rest, "{}") {
+		return errors.New("file name template contains unknown placeholders: " + template)
+	}
+	if path.IsAbs(template) || strings.HasPrefix(path.Clean(template), "..") {
+		return errors.New("file name template must be relative to the base path: " + template)
+	}
+	before, after, _ := strings.Cut(template, FileNameTemplateTime)
+	render := strings.NewReplacer(FileNameTemplatePrefix, w.baseFilePrefix, FileNameTemplateExt, w.baseFileExt)
+	w.fileNameBeforeTime = render.Replace(before)
+	w.fileNameAfterTime = render.Replace(after)
+	return nil
+}
@@ -84,3 +84,148 @@ func TestTimeRollingFileWriter_Rotate(t *testing.T) {
 		t.Fatalf("Expected 5 file, got %d", len(files))
 	}
 }
+
+func TestTimeRollingFileWriter_ActiveFileSurvives(t *testing.T) {
+	// Create a temporary directory
+	tempDir, err := os.MkdirTemp("", "filewriter_test")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// With no backups kept, the delete check time equals the next check time,
+	// so the active file is always older than it.
+	writer, err := NewTimeRollingFileWriter(tempDir, "test.log", 0, RollingPeriodSecond)
+	if err != nil {
+		t.Fatalf("Failed to create TimeRollingFileWriter: %v", err)
+	}
+	defer writer.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err = writer.Write([]byte("Hello, World!"))
+		if err != nil {
+			t.Fatalf("Failed to write data: %v", err)
+		}
+
+		// Give the background deletion a chance to run
+		time.Sleep(100 * time.Millisecond)
+
+		writer.mu.Lock()
+		activeFile := writer.file.Name()
+		writer.mu.Unlock()
+		if _, err = os.Stat(activeFile); err != nil {
+			t.Fatalf("Active file %s was deleted: %v", activeFile, err)
+		}
+
+		// Wait for 1 Second to trigger file rotation
+		time.Sleep(1 * time.Second)
+	}
+}
+
+func TestTimeRollingFileWriter_Template(t *testing.T) {
+	// Create a temporary directory
+	tempDir, err := os.MkdirTemp("", "filewriter_test")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writer, err := NewTimeRollingFileWriterWithTemplate(tempDir, "test.log", 1, RollingPeriodSecond, "{prefix}-{time}{ext}")
+	if err != nil {
+		t.Fatalf("Failed to create TimeRollingFileWriter: %v", err)
+	}
+	defer writer.Close()
+
+	expectedFile := func(now time.Time) string {
+		return filepath.Join(tempDir, "test-"+now.Format(TimeFormatSecond)+".log")
+	}
+	for i := 0; i < 3; i++ {
+		// The write may happen in either second if it crosses a second boundary
+		before := time.Now()
+		_, err = writer.Write([]byte("Hello, World!"))
+		if err != nil {
+			t.Fatalf("Failed to write data: %v", err)
+		}
+		after := time.Now()
+
+		// Ensure the file name follows the template
+		activeFile := writer.CurrentFile()
+		if activeFile != expectedFile(before) && activeFile != expectedFile(after) {
+			t.Fatalf("Unexpected file name, expected %s or %s, got %s", expectedFile(before), expectedFile(after), activeFile)
+		}
+
+		// Wait for 1 Second to trigger file rotation
+		time.Sleep(1 * time.Second)
+	}
+
+	// Ensure the old files are deleted
+	files, err := filepath.Glob(filepath.Join(tempDir, "test-*.log"))
+	if err != nil {
+		t.Fatalf("Failed to glob files: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(files))
+	}
+}
+
+func TestTimeRollingFileWriter_TemplateSubdirectory(t *testing.T) {
+	// Create a temporary directory
+	tempDir, err := os.MkdirTemp("", "filewriter_test")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	writer, err := NewTimeRollingFileWriterWithTemplate(tempDir, "test.log", 1, RollingPeriodSecond, "{time}/{prefix}{ext}")
+	if err != nil {
+		t.Fatalf("Failed to create TimeRollingFileWriter: %v", err)
+	}
+	defer writer.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err = writer.Write([]byte("Hello, World!"))
+		if err != nil {
+			t.Fatalf("Failed to write data: %v", err)
+		}
+
+		// Wait for 1 Second to trigger file rotation
+		time.Sleep(1 * time.Second)
+	}
+
+	// Ensure the old files and their directories are deleted
+	files, err := filepath.Glob(filepath.Join(tempDir, "*", "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to glob files: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file, got %d", len(files))
+	}
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 directory, got %d", len(entries))
+	}
+}
+
+func TestTimeRollingFileWriter_InvalidTemplate(t *testing.T) {
+	// Create a temporary directory
+	tempDir, err := os.MkdirTemp("", "filewriter_test")
+	if err != nil {
+		t.Fatalf("Failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, template := range []string{
+		"{prefix}{ext}",
+		"{time}-{time}{ext}",
+		"{prefix}-{date}-{time}{ext}",
+		"../{prefix}.{time}{ext}",
+		"/{prefix}.{time}{ext}",
+	} {
+		if _, err = NewTimeRollingFileWriterWithTemplate(tempDir, "test.log", 1, RollingPeriodDay, template); err == nil {
+			t.Fatalf("Expected an error for template %s", template)
+		}
+	}
+}